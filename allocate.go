@@ -0,0 +1,95 @@
+package decimal
+
+import (
+	"math/big"
+	"sort"
+)
+
+// Allocate splits total into parts proportional to weights, rounded to the
+// scale, digits. The parts add up to total rounded to digits exactly: the
+// units left over by rounding down go to the parts with the largest
+// remainders (largest remainder method), ties go to the earlier part.
+// If the weights add up to zero, total is split evenly.
+func Allocate(total Decimal, weights []Decimal, digits int) []Decimal {
+	if len(weights) == 0 {
+		return nil
+	}
+	if !total.native().IsFinite() {
+		parts := make([]Decimal, len(weights))
+		for i := range parts {
+			parts[i] = NewFromDecimal(total)
+		}
+		return parts
+	}
+	// unit is the value of the last digit, 10 ** -digits
+	unit := new(big.Rat).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(abs(digits))), nil))
+	if digits > 0 {
+		unit.Inv(unit)
+	}
+	t := QuantizeMode(total, digits, ToNearestEven)
+	units := new(big.Rat).Quo(t.native().Rat(nil), unit).Num()
+
+	ratios := make([]*big.Rat, len(weights))
+	sum := new(big.Rat)
+	for i, w := range weights {
+		ratios[i] = w.native().Rat(nil)
+		sum.Add(sum, ratios[i])
+	}
+	if sum.Sign() == 0 {
+		for i := range ratios {
+			ratios[i].SetInt64(1)
+		}
+		sum.SetInt64(int64(len(ratios)))
+	}
+
+	shares := make([]*big.Int, len(weights))
+	remainders := make([]*big.Rat, len(weights))
+	remaining := new(big.Int).Set(units)
+	for i, r := range ratios {
+		exact := new(big.Rat).Mul(new(big.Rat).SetInt(units), r)
+		exact.Quo(exact, sum)
+		// Div rounds towards negative infinity for the positive denominator
+		shares[i] = new(big.Int).Div(exact.Num(), exact.Denom())
+		remainders[i] = exact.Sub(exact, new(big.Rat).SetInt(shares[i]))
+		remaining.Sub(remaining, shares[i])
+	}
+	order := make([]int, len(weights))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return remainders[order[a]].Cmp(remainders[order[b]]) > 0
+	})
+	for i := 0; remaining.Sign() > 0; i++ {
+		shares[order[i%len(order)]].Add(shares[order[i%len(order)]], big.NewInt(1))
+		remaining.Sub(remaining, big.NewInt(1))
+	}
+
+	parts := make([]Decimal, len(weights))
+	for i, s := range shares {
+		parts[i] = newFromRat(new(big.Rat).Mul(new(big.Rat).SetInt(s), unit), digits, ToZero)
+	}
+	return parts
+}
+
+// Allocate splits m into parts proportional to weights, rounded to the minor
+// unit of its currency, that add up to m exactly. See Allocate.
+func (m Money) Allocate(weights []Decimal) []Money {
+	digits := m.MinorUnits()
+	if digits == NoMinorUnits {
+		digits = m.amount.Scale()
+	}
+	amounts := Allocate(m.amount, weights, digits)
+	parts := make([]Money, len(amounts))
+	for i, a := range amounts {
+		parts[i] = Money{amount: a, currency: m.currency}
+	}
+	return parts
+}
+
+func abs(i int) int {
+	if i < 0 {
+		return -i
+	}
+	return i
+}
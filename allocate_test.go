@@ -0,0 +1,54 @@
+package decimal
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func decimalStrings(list []Decimal) []string {
+	s := make([]string, len(list))
+	for i, d := range list {
+		s[i] = d.String()
+	}
+	return s
+}
+
+func TestAllocate(t *testing.T) {
+	testData := []struct {
+		total    string
+		weights  []string
+		digits   int
+		expected []string
+	}{
+		{total: "100", weights: []string{"1", "1", "1"}, digits: 2, expected: []string{"33.34", "33.33", "33.33"}},
+		{total: "0.05", weights: []string{"3", "7"}, digits: 2, expected: []string{"0.02", "0.03"}},
+		{total: "10", weights: []string{"19.99", "5.01", "75"}, digits: 2, expected: []string{"2.00", "0.50", "7.50"}},
+		{total: "-10", weights: []string{"1", "2"}, digits: 2, expected: []string{"-3.33", "-6.67"}},
+		{total: "10", weights: []string{"0", "0"}, digits: 0, expected: []string{"5", "5"}},
+		{total: "1", weights: []string{"0", "1", "0"}, digits: 2, expected: []string{"0", "1.00", "0"}},
+		{total: "1000", weights: []string{"1", "1", "1"}, digits: -2, expected: []string{"400", "300", "300"}},
+		{total: "1.005", weights: []string{"1", "1"}, digits: 2, expected: []string{"0.50", "0.50"}},
+	}
+	for i, j := range testData {
+		weights := make([]Decimal, len(j.weights))
+		for k, w := range j.weights {
+			weights[k] = MustNewFromString(w)
+		}
+		parts := Allocate(MustNewFromString(j.total), weights, j.digits)
+		require.Equal(t, j.expected, decimalStrings(parts), "At %d", i)
+		require.Equal(t, j.weights[0], weights[0].String(), "At %d", i)
+	}
+	require.Nil(t, Allocate(NewFromInt(1), nil, 2))
+}
+
+func TestMoneyAllocate(t *testing.T) {
+	parts := MustParseMoney("100 EUR").Allocate([]Decimal{NewFromInt(1), NewFromInt(1), NewFromInt(1)})
+	require.Len(t, parts, 3)
+	require.Equal(t, "33.34 EUR", parts[0].String())
+	require.Equal(t, "33.33 EUR", parts[2].String())
+
+	parts = MustParseMoney("100 JPY").Allocate([]Decimal{NewFromInt(1), NewFromInt(2)})
+	require.Equal(t, "33 JPY", parts[0].String())
+	require.Equal(t, "67 JPY", parts[1].String())
+}
@@ -0,0 +1,183 @@
+package decimal
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/big"
+
+	"github.com/ericlagergren/decimal"
+)
+
+// binaryVersion is the first byte of the binary format, see AppendBinary
+const binaryVersion = 1
+
+const (
+	binaryFinite   = 0
+	binaryInfinity = 1
+	binaryQuietNaN = 2
+	binarySignaNaN = 3
+	binaryKindMask = 3
+	binaryBig      = 1 << 2
+	binaryNegative = 1 << 3
+)
+
+// binaryPlus and binaryMinus are the signs for CopySign, never modified
+var binaryPlus, binaryMinus = decimal.New(1, 0), decimal.New(-1, 0)
+
+// AppendBinary appends the binary encoding of d to dst and returns the
+// extended buffer, it implements encoding.BinaryAppender. The format, version
+// 1, is
+//
+//	byte 0     version, 1
+//	byte 1     flags
+//	           bits 0-1  kind: 0 finite, 1 infinity, 2 quiet NaN, 3 signaling NaN
+//	           bit 2     coefficient format: 0 uvarint, 1 big-endian bytes
+//	           bit 3     sign, set for negative values including -0
+//	           bits 4-7  reserved, zero
+//
+// followed for finite values by
+//
+//	varint     exponent, the negated scale, so 1.50 has exponent -2
+//	uvarint    coefficient if bit 2 is clear, else
+//	uvarint    length n of the coefficient
+//	n bytes    coefficient, unsigned big-endian
+//
+// The value is (-1)^sign * coefficient * 10^exponent. uvarint and varint are
+// the variable length encodings of encoding/binary, also used by Protocol
+// Buffers: 7 bits per byte, least significant group first, the high bit set
+// on all but the last byte, and varint maps the signed n to the unsigned
+// (n << 1) ^ (n >> 63) (zigzag). The coefficient is written as uvarint if it
+// fits in 64 bits. NaN payloads are not encoded.
+func (d Decimal) AppendBinary(dst []byte) ([]byte, error) {
+	x := d.native()
+	var flags byte
+	if x.Signbit() {
+		flags |= binaryNegative
+	}
+	switch {
+	case x.IsNaN(1):
+		return append(dst, binaryVersion, flags|binaryQuietNaN), nil
+	case x.IsNaN(-1):
+		return append(dst, binaryVersion, flags|binarySignaNaN), nil
+	case x.IsInf(0):
+		return append(dst, binaryVersion, flags|binaryInfinity), nil
+	}
+
+	var mant decimal.Big
+	mant.CopySign(x, binaryPlus)
+	mant.SetScale(0)
+	var buf [binary.MaxVarintLen64]byte
+	if u, ok := mant.Uint64(); ok {
+		dst = append(dst, binaryVersion, flags)
+		dst = append(dst, buf[:binary.PutVarint(buf[:], -int64(x.Scale()))]...)
+		return append(dst, buf[:binary.PutUvarint(buf[:], u)]...), nil
+	}
+	coefficient := mant.Int(nil).Bytes()
+	dst = append(dst, binaryVersion, flags|binaryBig)
+	dst = append(dst, buf[:binary.PutVarint(buf[:], -int64(x.Scale()))]...)
+	dst = append(dst, buf[:binary.PutUvarint(buf[:], uint64(len(coefficient)))]...)
+	return append(dst, coefficient...), nil
+}
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface, it returns
+// the format described at AppendBinary, so 1.50 is 01 00 03 96 01
+func (d Decimal) MarshalBinary() ([]byte, error) {
+	return d.AppendBinary(make([]byte, 0, 16))
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface. It
+// fails on unknown versions, reserved flags, truncated input, trailing bytes
+// and exponents out of range without modifying d. Like text input the
+// exponent in scientific notation is limited to the MaxExponent and the
+// coefficient to the MaxLength digits of the DefaultParser.
+func (d *Decimal) UnmarshalBinary(buf []byte) error {
+	invalid := func(reason string) error {
+		return fmt.Errorf("Invalid binary decimal: %s", reason)
+	}
+	switch {
+	case len(buf) < 2:
+		return invalid("too short")
+	case buf[0] != binaryVersion:
+		return invalid(fmt.Sprintf("unsupported version %d", buf[0]))
+	case buf[1]&^(binaryKindMask|binaryBig|binaryNegative) != 0:
+		return invalid(fmt.Sprintf("reserved flags %#02x", buf[1]))
+	}
+	flags := buf[1]
+	negative := flags&binaryNegative != 0
+	if kind := flags & binaryKindMask; kind != binaryFinite {
+		if len(buf) > 2 {
+			return invalid("trailing bytes")
+		}
+		if flags&binaryBig != 0 {
+			return invalid(fmt.Sprintf("reserved flags %#02x", flags))
+		}
+		x := decimal.New(0, 0)
+		switch kind {
+		case binaryInfinity:
+			x.SetInf(negative)
+		case binaryQuietNaN:
+			x.SetNaN(false)
+		default:
+			x.SetNaN(true)
+		}
+		if negative {
+			x.CopySign(x, binaryMinus)
+		}
+		d.nat = x
+		return nil
+	}
+
+	buf = buf[2:]
+	exp, n := binary.Varint(buf)
+	if n <= 0 {
+		return invalid("truncated exponent")
+	}
+	buf = buf[n:]
+	if exp < decimal.MinScale || exp > decimal.MaxScale {
+		return invalid(fmt.Sprintf("exponent %d out of range", exp))
+	}
+
+	x := decimal.New(0, 0)
+	if flags&binaryBig == 0 {
+		u, n := binary.Uvarint(buf)
+		if n <= 0 {
+			return invalid("truncated coefficient")
+		}
+		buf = buf[n:]
+		x.SetUint64(u)
+		x.SetScale(int(-exp))
+	} else {
+		length, n := binary.Uvarint(buf)
+		if n <= 0 {
+			return invalid("truncated coefficient length")
+		}
+		buf = buf[n:]
+		if length > uint64(len(buf)) {
+			return invalid("truncated coefficient")
+		}
+		// like text input the coefficient has at most MaxLength digits,
+		// which take MaxLength * log2(10) bits
+		if max := DefaultParser().Options().MaxLength; max > 0 {
+			if limit := (uint64(max)*332193 + 799999) / 800000; length > limit {
+				return invalid(fmt.Sprintf("coefficient of %d bytes exceeds %d", length, limit))
+			}
+		}
+		x.SetBigMantScale(new(big.Int).SetBytes(buf[:length]), int(-exp))
+		buf = buf[length:]
+	}
+	if len(buf) > 0 {
+		return invalid("trailing bytes")
+	}
+	adjusted := exp + int64(x.Precision()) - 1
+	if adjusted < 0 {
+		adjusted = -adjusted
+	}
+	if max := DefaultParser().Options().MaxExponent; max > 0 && adjusted > int64(max) {
+		return invalid(fmt.Sprintf("exponent exceeds %d", max))
+	}
+	if negative {
+		x.CopySign(x, binaryMinus)
+	}
+	d.nat = x
+	return nil
+}
@@ -0,0 +1,24 @@
+//go:build go1.18
+// +build go1.18
+
+package decimal_test
+
+import (
+	"testing"
+
+	"github.com/talon-one/decimal"
+)
+
+func FuzzUnmarshalBinary(f *testing.F) {
+	for _, s := range []string{"0", "-0", "1.50", "-1E+9", "18446744073709551616", "Infinity"} {
+		buf, err := decimal.MustNewFromString(s).MarshalBinary()
+		if err != nil {
+			f.Fatal(err)
+		}
+		f.Add(buf)
+	}
+	f.Add([]byte{1, 2})
+	f.Add([]byte{1, 4, 0, 0xff, 0x01})
+	f.Add(append([]byte{1, 4, 0, 0xab, 0x03}, make([]byte, 427)...))
+	f.Fuzz(checkUnmarshalBinary)
+}
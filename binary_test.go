@@ -0,0 +1,176 @@
+package decimal_test
+
+import (
+	"encoding"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/talon-one/decimal"
+)
+
+var _ encoding.BinaryMarshaler = decimal.Decimal{}
+var _ encoding.BinaryUnmarshaler = (*decimal.Decimal)(nil)
+
+func TestMarshalBinary(t *testing.T) {
+	testData := []struct {
+		value    string
+		expected []byte
+	}{
+		{value: "0", expected: []byte{1, 0, 0, 0}},
+		{value: "-0", expected: []byte{1, 8, 0, 0}},
+		{value: "0.000", expected: []byte{1, 0, 5, 0}},
+		{value: "1.50", expected: []byte{1, 0, 3, 0x96, 0x01}},
+		{value: "-1.50", expected: []byte{1, 8, 3, 0x96, 0x01}},
+		{value: "1E+3", expected: []byte{1, 0, 6, 1}},
+		{value: "18446744073709551615", expected: []byte{1, 0, 0, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x01}},
+		{value: "18446744073709551616", expected: []byte{1, 4, 0, 9, 1, 0, 0, 0, 0, 0, 0, 0, 0}},
+		{value: "-1844674407370955161.6", expected: []byte{1, 12, 1, 9, 1, 0, 0, 0, 0, 0, 0, 0, 0}},
+		{value: "Infinity", expected: []byte{1, 1}},
+		{value: "-Infinity", expected: []byte{1, 9}},
+	}
+	data := setup(func() []string {
+		values := make([]string, len(testData))
+		for i := range testData {
+			values[i] = testData[i].value
+		}
+		return values
+	}()...)
+	for i, j := range testData {
+		buf, err := data.Decimals[i].MarshalBinary()
+		require.NoError(t, err, "At %d", i)
+		require.Equal(t, j.expected, buf, "At %d", i)
+
+		var d decimal.Decimal
+		require.NoError(t, d.UnmarshalBinary(buf), "At %d", i)
+		require.Equal(t, data.Decimals[i].String(), d.String(), "At %d", i)
+		require.Equal(t, data.Decimals[i].GoString(), d.GoString(), "At %d", i)
+	}
+	data.VerifyIntegrity(t)
+
+	buf, err := decimal.Decimal{}.MarshalBinary()
+	require.NoError(t, err)
+	require.Equal(t, []byte{1, 0, 0, 0}, buf)
+
+	for i, v := range []string{"1E+6144", "-9.999E+6144", "1E-6144", "0E+6144", strings.Repeat("9", 1024)} {
+		buf, err := decimal.MustNewFromString(v).MarshalBinary()
+		require.NoError(t, err, "At %d", i)
+		var d decimal.Decimal
+		require.NoError(t, d.UnmarshalBinary(buf), "At %d", i)
+		require.Equal(t, decimal.MustNewFromString(v).GoString(), d.GoString(), "At %d", i)
+	}
+
+	buf, err = decimal.New(7, 0).AppendBinary([]byte("x"))
+	require.NoError(t, err)
+	require.Equal(t, []byte{'x', 1, 0, 0, 7}, buf)
+}
+
+func TestMarshalBinaryNaN(t *testing.T) {
+	nan := decimal.DivMode(decimal.Zero(), decimal.Zero(), 0, decimal.ToNearestEven)
+	buf, err := nan.MarshalBinary()
+	require.NoError(t, err)
+	var d decimal.Decimal
+	require.NoError(t, d.UnmarshalBinary(buf))
+	require.True(t, d.IsNaN())
+
+	for i, b := range [][]byte{{1, 2}, {1, 3}, {1, 10}, {1, 11}} {
+		require.NoError(t, d.UnmarshalBinary(b), "At %d", i)
+		require.True(t, d.IsNaN(), "At %d", i)
+		buf, err := d.MarshalBinary()
+		require.NoError(t, err, "At %d", i)
+		require.Equal(t, b, buf, "At %d", i)
+	}
+}
+
+func TestMarshalBinaryRoundTrip(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	digits := []byte("0123456789")
+	for i := 0; i < 1000; i++ {
+		s := make([]byte, 1+rnd.Intn(60))
+		for k := range s {
+			s[k] = digits[rnd.Intn(10)]
+		}
+		v := string(s)
+		if p := rnd.Intn(len(s) + 1); p < len(s) {
+			v = v[:p] + "." + v[p:] + "0"
+		}
+		if rnd.Intn(2) == 0 {
+			v = "-" + v
+		}
+		d := decimal.MustNewFromString(v + "E" + []string{"+", "-"}[rnd.Intn(2)] + string(digits[rnd.Intn(10)]))
+		buf, err := d.MarshalBinary()
+		require.NoError(t, err, "At %d", i)
+		var parsed decimal.Decimal
+		require.NoError(t, parsed.UnmarshalBinary(buf), "At %d", i)
+		require.Equal(t, d.GoString(), parsed.GoString(), "At %d", i)
+	}
+}
+
+func TestUnmarshalBinaryErrors(t *testing.T) {
+	testData := []struct {
+		input []byte
+		err   string
+	}{
+		{input: nil, err: "too short"},
+		{input: []byte{1}, err: "too short"},
+		{input: []byte{2, 0, 0, 0}, err: "unsupported version 2"},
+		{input: []byte{0, 0, 0, 0}, err: "unsupported version 0"},
+		{input: []byte{1, 0x10, 0, 0}, err: "reserved flags 0x10"},
+		{input: []byte{1, 5}, err: "reserved flags 0x05"},
+		{input: []byte{1, 1, 0}, err: "trailing bytes"},
+		{input: []byte{1, 0}, err: "truncated exponent"},
+		{input: []byte{1, 0, 0x80}, err: "truncated exponent"},
+		{input: []byte{1, 0, 0}, err: "truncated coefficient"},
+		{input: []byte{1, 0, 0, 0x80}, err: "truncated coefficient"},
+		{input: []byte{1, 0, 0, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x01}, err: "truncated coefficient"},
+		{input: []byte{1, 0, 0, 1, 0}, err: "trailing bytes"},
+		{input: []byte{1, 4, 0}, err: "truncated coefficient length"},
+		{input: []byte{1, 4, 0, 3, 1, 2}, err: "truncated coefficient"},
+		{input: []byte{1, 4, 0, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x01}, err: "truncated coefficient"},
+		{input: []byte{1, 4, 0, 1, 1, 1}, err: "trailing bytes"},
+		{input: []byte{1, 0, 0x80, 0xb4, 0x89, 0x13, 1}, err: "exponent exceeds 6144"},
+		{input: []byte{1, 0, 0xff, 0xb3, 0x89, 0x13, 1}, err: "exponent exceeds 6144"},
+		{input: []byte{1, 0, 0x82, 0x60, 1}, err: "exponent exceeds 6144"},
+		{input: []byte{1, 4, 0x80, 0x60, 2, 0x03, 0xe8}, err: "exponent exceeds 6144"},
+		{input: []byte{1, 0, 0xfe, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x01, 1}, err: "exponent 9223372036854775807 out of range"},
+		{input: append([]byte{1, 4, 0, 0xab, 0x03}, make([]byte, 427)...), err: "coefficient of 427 bytes exceeds 426"},
+	}
+	for i, j := range testData {
+		d := decimal.New(7, 0)
+		require.EqualError(t, d.UnmarshalBinary(j.input), "Invalid binary decimal: "+j.err, "At %d", i)
+		require.Equal(t, "7", d.String(), "At %d", i)
+	}
+}
+
+// checkUnmarshalBinary checks that buf is rejected or decodes to a value
+// whose encoding decodes to the same value
+func checkUnmarshalBinary(t *testing.T, buf []byte) {
+	var d decimal.Decimal
+	if d.UnmarshalBinary(buf) != nil {
+		return
+	}
+	encoded, err := d.MarshalBinary()
+	require.NoError(t, err)
+	var again decimal.Decimal
+	require.NoError(t, again.UnmarshalBinary(encoded))
+	reencoded, err := again.MarshalBinary()
+	require.NoError(t, err)
+	require.Equal(t, encoded, reencoded)
+}
+
+func TestUnmarshalBinaryMalformed(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	valid, err := decimal.MustNewFromString("-123456789012345678901234567890.12345").MarshalBinary()
+	require.NoError(t, err)
+	for i := 0; i < 10000; i++ {
+		buf := append([]byte(nil), valid[:rnd.Intn(len(valid)+1)]...)
+		for k := rnd.Intn(4); k > 0 && len(buf) > 0; k-- {
+			buf[rnd.Intn(len(buf))] = byte(rnd.Intn(256))
+		}
+		if rnd.Intn(4) == 0 {
+			buf = append(buf, byte(rnd.Intn(256)))
+		}
+		checkUnmarshalBinary(t, buf)
+	}
+}
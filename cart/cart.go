@@ -0,0 +1,245 @@
+// Package cart calculates the totals of a shopping cart or invoice with line
+// and cart discounts, taxes and rounding.
+//
+// All amounts are rounded to the minor unit of the currency of the cart. The
+// totals are sums of the line amounts, so the itemized breakdown reconciles
+// exactly with the grand total.
+package cart
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/talon-one/decimal"
+	"github.com/talon-one/decimal/tax"
+)
+
+// TaxMode determines whether prices include taxes
+type TaxMode int
+
+const (
+	// TaxExclusive means prices are net amounts, taxes are added to them
+	TaxExclusive TaxMode = iota
+	// TaxInclusive means prices are gross amounts that contain the taxes
+	TaxInclusive
+)
+
+// Line is an item of the cart
+type Line struct {
+	// SKU identifies the item
+	SKU       string
+	Quantity  decimal.Decimal
+	UnitPrice decimal.Decimal
+	// Discounts are stacked in order with decimal.StackDiscounts, each applies
+	// to the price left by the previous one. An amount discount is taken off
+	// the line, not off every unit.
+	Discounts []decimal.Discount
+	// TaxClass selects the tax rates of the line from Cart.TaxClasses. Lines
+	// without a tax class are not taxed.
+	TaxClass string
+}
+
+// Cart holds the lines and the calculation settings
+type Cart struct {
+	// Currency is the currency code of all prices
+	Currency string
+	Lines    []Line
+	// Discounts apply to the whole cart in order, each to the total left by
+	// the previous ones. Each is spread across the lines in proportion to
+	// their prices after the preceding discounts.
+	Discounts []decimal.Discount
+	TaxMode   TaxMode
+	// TaxClasses maps the tax class of a line to its rates
+	TaxClasses map[string][]tax.Rate
+	// Rounding determines whether taxes are rounded per line or on the total
+	// of every tax class
+	Rounding tax.Rounding
+	// Mode is the rounding mode of all amounts
+	Mode decimal.RoundingMode
+}
+
+// LineTotal is the breakdown of a line. Amount - LineDiscount - CartDiscount
+// is the price of the line, which is Net for TaxExclusive and Gross for
+// TaxInclusive carts. Net + Tax == Gross.
+type LineTotal struct {
+	Line Line
+	// Amount is Quantity times UnitPrice
+	Amount       decimal.Money
+	LineDiscount decimal.Money
+	CartDiscount decimal.Money
+	Net          decimal.Money
+	Tax          decimal.Money
+	Gross        decimal.Money
+	Taxes        []TaxAmount
+	// Discounts lists the line discounts in the order they were applied
+	Discounts []decimal.DiscountResult
+}
+
+// TaxAmount is the tax of a rate
+type TaxAmount struct {
+	Rate tax.Rate
+	Tax  decimal.Money
+}
+
+// Totals is the result of Calculate, every amount is the sum of the
+// respective amounts of the lines.
+type Totals struct {
+	Lines        []LineTotal
+	Amount       decimal.Money
+	LineDiscount decimal.Money
+	CartDiscount decimal.Money
+	Net          decimal.Money
+	Tax          decimal.Money
+	Gross        decimal.Money
+	// Taxes lists the tax of every rate, rates with the same name and ratio
+	// in different tax classes are combined
+	Taxes []TaxAmount
+	// Discounts lists the cart discounts applied to the total of the lines
+	Discounts []decimal.DiscountResult
+}
+
+// Calculate returns the totals of c
+func (c Cart) Calculate() (Totals, error) {
+	currency, ok := decimal.LookupCurrency(c.Currency)
+	if !ok {
+		return Totals{}, fmt.Errorf("Unknown currency `%s'", c.Currency)
+	}
+	digits := currency.MinorUnits
+	if digits == decimal.NoMinorUnits {
+		digits = decimal.ConversionDigits
+	}
+
+	// prices of the lines after line discounts
+	amounts := make([]decimal.Decimal, len(c.Lines))
+	prices := make([]decimal.Decimal, len(c.Lines))
+	lineDiscounts := make([][]decimal.DiscountResult, len(c.Lines))
+	for i, line := range c.Lines {
+		quantity, err := line.Quantity.Rat()
+		if err != nil || quantity.Sign() < 0 {
+			return Totals{}, fmt.Errorf("Invalid quantity %s of line %d", line.Quantity, i+1)
+		}
+		unitPrice, err := line.UnitPrice.Rat()
+		if err != nil || unitPrice.Sign() < 0 {
+			return Totals{}, fmt.Errorf("Invalid unit price %s of line %d", line.UnitPrice, i+1)
+		}
+		if _, ok := c.TaxClasses[line.TaxClass]; !ok && line.TaxClass != "" {
+			return Totals{}, fmt.Errorf("Unknown tax class `%s' of line %d", line.TaxClass, i+1)
+		}
+		amounts[i] = multiply(quantity, unitPrice, digits, c.Mode)
+		stack := decimal.StackDiscounts(amounts[i], line.Discounts, decimal.InGivenOrder, digits, c.Mode)
+		prices[i] = stack.Result
+		lineDiscounts[i] = stack.Steps
+	}
+
+	// cart discounts spread across the lines
+	cartDiscounts := make([]decimal.Decimal, len(c.Lines))
+	for i := range cartDiscounts {
+		cartDiscounts[i] = decimal.Zero()
+	}
+	discounts := make([]decimal.DiscountResult, len(c.Discounts))
+	for k, d := range c.Discounts {
+		discounts[k] = d.Apply(sum(prices), digits, c.Mode)
+		for i, share := range decimal.Allocate(discounts[k].Discount, prices, digits) {
+			prices[i] = decimal.Sub(prices[i], share)
+			cartDiscounts[i] = decimal.Add(cartDiscounts[i], share)
+		}
+	}
+
+	// taxes by tax class
+	results := make([]tax.Result, len(c.Lines))
+	classes := make(map[string][]int)
+	var order []string
+	for i, line := range c.Lines {
+		if _, ok := classes[line.TaxClass]; !ok {
+			order = append(order, line.TaxClass)
+		}
+		classes[line.TaxClass] = append(classes[line.TaxClass], i)
+	}
+	for _, class := range order {
+		indices := classes[class]
+		classPrices := make([]decimal.Decimal, len(indices))
+		for k, i := range indices {
+			classPrices[k] = prices[i]
+		}
+		var invoice tax.Invoice
+		var err error
+		if c.TaxMode == TaxInclusive {
+			invoice, err = tax.ExtractTaxFromLines(classPrices, c.Rounding, digits, c.Mode, c.TaxClasses[class]...)
+		} else {
+			invoice, err = tax.AddTaxToLines(classPrices, c.Rounding, digits, c.Mode, c.TaxClasses[class]...)
+		}
+		if err != nil {
+			return Totals{}, err
+		}
+		for k, i := range indices {
+			results[i] = invoice.Lines[k]
+		}
+	}
+
+	money := func(d decimal.Decimal) decimal.Money {
+		return decimal.MustNewMoney(decimal.QuantizeMode(d, digits, c.Mode), currency.Code)
+	}
+	zero := money(decimal.Zero())
+	totals := Totals{
+		Lines:        make([]LineTotal, len(c.Lines)),
+		Amount:       zero,
+		LineDiscount: zero,
+		CartDiscount: zero,
+		Net:          zero,
+		Tax:          zero,
+		Gross:        zero,
+		Discounts:    discounts,
+	}
+	for i, line := range c.Lines {
+		lt := LineTotal{
+			Line:         line,
+			Amount:       money(amounts[i]),
+			LineDiscount: money(decimal.Sub(decimal.Sub(amounts[i], prices[i]), cartDiscounts[i])),
+			CartDiscount: money(cartDiscounts[i]),
+			Net:          money(results[i].Net),
+			Tax:          money(results[i].Tax),
+			Gross:        money(results[i].Gross),
+			Discounts:    lineDiscounts[i],
+		}
+		for _, t := range results[i].Taxes {
+			lt.Taxes = append(lt.Taxes, TaxAmount{Rate: t.Rate, Tax: money(t.Tax)})
+			totals.Taxes = addTax(totals.Taxes, TaxAmount{Rate: t.Rate, Tax: money(t.Tax)})
+		}
+		totals.Lines[i] = lt
+		totals.Amount, _ = totals.Amount.Add(lt.Amount)
+		totals.LineDiscount, _ = totals.LineDiscount.Add(lt.LineDiscount)
+		totals.CartDiscount, _ = totals.CartDiscount.Add(lt.CartDiscount)
+		totals.Net, _ = totals.Net.Add(lt.Net)
+		totals.Tax, _ = totals.Tax.Add(lt.Tax)
+		totals.Gross, _ = totals.Gross.Add(lt.Gross)
+	}
+	return totals, nil
+}
+
+// addTax adds t to the amount of the same rate in taxes or appends it
+func addTax(taxes []TaxAmount, t TaxAmount) []TaxAmount {
+	for i, existing := range taxes {
+		if existing.Rate.Name == t.Rate.Name && existing.Rate.Rate.Equals(t.Rate.Rate) && existing.Rate.Compound == t.Rate.Compound {
+			taxes[i].Tax, _ = existing.Tax.Add(t.Tax)
+			return taxes
+		}
+	}
+	return append(taxes, t)
+}
+
+// multiply returns the exact product of a and b rounded to digits with mode.
+// Mul rounds to the default precision, which may drop digits of large amounts.
+func multiply(a, b *big.Rat, digits int, mode decimal.RoundingMode) decimal.Decimal {
+	p := new(big.Rat).Mul(a, b)
+	num := decimal.MustNewFromString(p.Num().String())
+	den := decimal.MustNewFromString(p.Denom().String())
+	return decimal.DivMode(num, den, digits, mode)
+}
+
+func sum(list []decimal.Decimal) decimal.Decimal {
+	total := decimal.Zero()
+	for _, d := range list {
+		total = decimal.Add(total, d)
+	}
+	return total
+}
@@ -0,0 +1,250 @@
+package cart_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/talon-one/decimal"
+	"github.com/talon-one/decimal/cart"
+	"github.com/talon-one/decimal/tax"
+)
+
+func d(s string) decimal.Decimal {
+	return decimal.MustNewFromString(s)
+}
+
+var taxClasses = map[string][]tax.Rate{
+	"standard": {tax.NewRate("VAT", d("0.19"))},
+	"reduced":  {tax.NewRate("VAT", d("0.07"))},
+}
+
+// reconcile checks that the breakdown of totals adds up exactly
+func reconcile(t *testing.T, c cart.Cart, totals cart.Totals) {
+	add := func(a, b decimal.Money) decimal.Money {
+		sum, err := a.Add(b)
+		require.NoError(t, err)
+		return sum
+	}
+	sub := func(a, b decimal.Money) decimal.Money {
+		diff, err := a.Sub(b)
+		require.NoError(t, err)
+		return diff
+	}
+	price := func(l cart.LineTotal) decimal.Money {
+		return sub(sub(l.Amount, l.LineDiscount), l.CartDiscount)
+	}
+	var amount, lineDiscount, cartDiscount, net, tx, gross, taxes decimal.Money
+	for i, l := range totals.Lines {
+		if i == 0 {
+			amount, lineDiscount, cartDiscount, net, tx, gross = l.Amount, l.LineDiscount, l.CartDiscount, l.Net, l.Tax, l.Gross
+		} else {
+			amount, lineDiscount, cartDiscount = add(amount, l.Amount), add(lineDiscount, l.LineDiscount), add(cartDiscount, l.CartDiscount)
+			net, tx, gross = add(net, l.Net), add(tx, l.Tax), add(gross, l.Gross)
+		}
+		require.True(t, add(l.Net, l.Tax).Equals(l.Gross), "Line %d: %s + %s ≠ %s", i, l.Net, l.Tax, l.Gross)
+		if c.TaxMode == cart.TaxInclusive {
+			require.True(t, price(l).Equals(l.Gross), "Line %d", i)
+		} else {
+			require.True(t, price(l).Equals(l.Net), "Line %d", i)
+		}
+		require.False(t, l.LineDiscount.IsNegative(), "Line %d", i)
+		require.False(t, l.CartDiscount.IsNegative(), "Line %d", i)
+	}
+	for i, tt := range totals.Taxes {
+		if i == 0 {
+			taxes = tt.Tax
+		} else {
+			taxes = add(taxes, tt.Tax)
+		}
+	}
+	require.True(t, amount.Equals(totals.Amount))
+	require.True(t, lineDiscount.Equals(totals.LineDiscount))
+	require.True(t, cartDiscount.Equals(totals.CartDiscount))
+	require.True(t, net.Equals(totals.Net))
+	require.True(t, tx.Equals(totals.Tax))
+	require.True(t, gross.Equals(totals.Gross))
+	require.True(t, add(totals.Net, totals.Tax).Equals(totals.Gross))
+	if len(totals.Taxes) > 0 {
+		require.True(t, taxes.Equals(totals.Tax), "%s ≠ %s", taxes, totals.Tax)
+	}
+}
+
+func TestCalculate(t *testing.T) {
+	c := cart.Cart{
+		Currency: "EUR",
+		Lines: []cart.Line{
+			{SKU: "A", Quantity: d("3"), UnitPrice: d("19.99"), TaxClass: "standard",
+				Discounts: []decimal.Discount{decimal.PercentDiscount("summer", decimal.MustParsePercent("10%"))}},
+			{SKU: "B", Quantity: d("2"), UnitPrice: d("4.50"), TaxClass: "reduced"},
+			{SKU: "C", Quantity: d("1"), UnitPrice: d("100"), TaxClass: "standard",
+				Discounts: []decimal.Discount{decimal.AmountDiscount("voucher", d("15"))}},
+		},
+		Discounts:  []decimal.Discount{decimal.AmountDiscount("welcome", d("10"))},
+		TaxClasses: taxClasses,
+	}
+	totals, err := c.Calculate()
+	require.NoError(t, err)
+	reconcile(t, c, totals)
+
+	require.Equal(t, "59.97 EUR", totals.Lines[0].Amount.String())
+	require.Equal(t, "6.00 EUR", totals.Lines[0].LineDiscount.String())
+	require.Equal(t, "3.65 EUR", totals.Lines[0].CartDiscount.String())
+	require.Equal(t, "50.32 EUR", totals.Lines[0].Net.String())
+	require.Equal(t, "9.56 EUR", totals.Lines[0].Tax.String())
+	require.Equal(t, "0.61 EUR", totals.Lines[1].CartDiscount.String())
+	require.Equal(t, "0.59 EUR", totals.Lines[1].Tax.String())
+	require.Equal(t, "79.26 EUR", totals.Lines[2].Net.String())
+
+	require.Equal(t, "168.97 EUR", totals.Amount.String())
+	require.Equal(t, "21.00 EUR", totals.LineDiscount.String())
+	require.Equal(t, "10.00 EUR", totals.CartDiscount.String())
+	require.Equal(t, "137.97 EUR", totals.Net.String())
+	require.Equal(t, "25.21 EUR", totals.Tax.String())
+	require.Equal(t, "163.18 EUR", totals.Gross.String())
+	require.Len(t, totals.Taxes, 2)
+	require.Equal(t, "24.62 EUR", totals.Taxes[0].Tax.String())
+	require.Equal(t, "0.59 EUR", totals.Taxes[1].Tax.String())
+}
+
+func TestCalculateInclusive(t *testing.T) {
+	c := cart.Cart{
+		Currency: "CHF",
+		Lines: []cart.Line{
+			{SKU: "A", Quantity: d("3"), UnitPrice: d("9.95"), TaxClass: "standard"},
+			{SKU: "B", Quantity: d("0.5"), UnitPrice: d("3.99"), TaxClass: "standard"},
+			{SKU: "gift card", Quantity: d("1"), UnitPrice: d("50")},
+		},
+		Discounts:  []decimal.Discount{decimal.PercentDiscount("staff", decimal.MustParsePercent("20%"))},
+		TaxMode:    cart.TaxInclusive,
+		TaxClasses: map[string][]tax.Rate{"standard": {tax.NewRate("VAT", d("0.081"))}},
+		Rounding:   tax.PerTotal,
+		Mode:       decimal.ToNearestAway,
+	}
+	totals, err := c.Calculate()
+	require.NoError(t, err)
+	reconcile(t, c, totals)
+
+	require.Equal(t, "29.85 CHF", totals.Lines[0].Amount.String())
+	require.Equal(t, "2.00 CHF", totals.Lines[1].Amount.String())
+	require.Equal(t, "81.85 CHF", totals.Amount.String())
+	require.Equal(t, "16.37 CHF", totals.CartDiscount.String())
+	require.Equal(t, "65.48 CHF", totals.Gross.String())
+	require.Equal(t, "0 CHF", totals.Lines[2].Tax.String())
+}
+
+func TestCalculateErrors(t *testing.T) {
+	_, err := cart.Cart{Currency: "XYZ"}.Calculate()
+	require.EqualError(t, err, "Unknown currency `XYZ'")
+
+	_, err = cart.Cart{Currency: "EUR", Lines: []cart.Line{{Quantity: d("-1"), UnitPrice: d("1")}}}.Calculate()
+	require.EqualError(t, err, "Invalid quantity -1 of line 1")
+
+	_, err = cart.Cart{Currency: "EUR", Lines: []cart.Line{{Quantity: d("Infinity"), UnitPrice: d("1")}}}.Calculate()
+	require.EqualError(t, err, "Invalid quantity Infinity of line 1")
+
+	_, err = cart.Cart{Currency: "EUR", Lines: []cart.Line{{Quantity: d("1"), UnitPrice: d("1")}, {Quantity: d("1"), UnitPrice: d("-Infinity")}}}.Calculate()
+	require.EqualError(t, err, "Invalid unit price -Infinity of line 2")
+
+	_, err = cart.Cart{Currency: "EUR", Lines: []cart.Line{{Quantity: d("1"), UnitPrice: d("1"), TaxClass: "food"}}}.Calculate()
+	require.EqualError(t, err, "Unknown tax class `food' of line 1")
+
+	totals, err := cart.Cart{Currency: "EUR"}.Calculate()
+	require.NoError(t, err)
+	require.True(t, totals.Gross.IsZero())
+}
+
+func TestDiscountsNeverExceedPrice(t *testing.T) {
+	c := cart.Cart{
+		Currency: "EUR",
+		Lines: []cart.Line{
+			{Quantity: d("1"), UnitPrice: d("5"), Discounts: []decimal.Discount{decimal.AmountDiscount("voucher", d("8"))}},
+			{Quantity: d("1"), UnitPrice: d("5")},
+		},
+		Discounts: []decimal.Discount{decimal.AmountDiscount("voucher", d("20"))},
+	}
+	totals, err := c.Calculate()
+	require.NoError(t, err)
+	reconcile(t, c, totals)
+	require.Equal(t, "5.00 EUR", totals.LineDiscount.String())
+	require.Equal(t, "5.00 EUR", totals.CartDiscount.String())
+	require.True(t, totals.Gross.IsZero())
+	require.True(t, totals.Lines[0].Discounts[0].Floored)
+	require.Equal(t, "5.00", totals.Lines[0].Discounts[0].Discount.String())
+	require.True(t, totals.Discounts[0].Floored)
+	require.Equal(t, "5.00", totals.Discounts[0].Discount.String())
+}
+
+func TestCartDiscountLimits(t *testing.T) {
+	c := cart.Cart{
+		Currency: "EUR",
+		Lines: []cart.Line{
+			{Quantity: d("2"), UnitPrice: d("40"), Discounts: []decimal.Discount{
+				decimal.PercentDiscount("sale", decimal.MustParsePercent("50%")).WithFloor(d("50")),
+				decimal.AmountDiscount("refund", d("-5")),
+			}},
+			{Quantity: d("1"), UnitPrice: d("20")},
+		},
+		Discounts: []decimal.Discount{
+			decimal.PercentDiscount("staff", decimal.MustParsePercent("25%")).WithCap(d("12")),
+			decimal.AmountDiscount("voucher", d("7")),
+		},
+	}
+	totals, err := c.Calculate()
+	require.NoError(t, err)
+	reconcile(t, c, totals)
+
+	steps := totals.Lines[0].Discounts
+	require.Len(t, steps, 2)
+	require.Equal(t, "30.00", steps[0].Discount.String())
+	require.True(t, steps[0].Floored)
+	require.Equal(t, "0", steps[1].Discount.String())
+	require.Equal(t, "30.00 EUR", totals.LineDiscount.String())
+	require.Empty(t, totals.Lines[1].Discounts)
+
+	require.Len(t, totals.Discounts, 2)
+	require.Equal(t, "70.00", totals.Discounts[0].Price.String())
+	require.Equal(t, "12.00", totals.Discounts[0].Discount.String())
+	require.True(t, totals.Discounts[0].Capped)
+	require.Equal(t, "58.00", totals.Discounts[1].Price.String())
+	require.Equal(t, "7.00", totals.Discounts[1].Discount.String())
+	require.Equal(t, "19.00 EUR", totals.CartDiscount.String())
+	require.Equal(t, "51.00 EUR", totals.Gross.String())
+}
+
+func TestCalculateReconciles(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	classes := []string{"", "standard", "reduced"}
+	for k := 0; k < 200; k++ {
+		c := cart.Cart{
+			Currency:   []string{"EUR", "JPY", "KWD"}[k%3],
+			TaxMode:    cart.TaxMode(k % 2),
+			TaxClasses: taxClasses,
+			Rounding:   tax.Rounding(k / 2 % 2),
+			Mode:       decimal.RoundingMode(rnd.Intn(6)),
+		}
+		for i := rnd.Intn(6); i >= 0; i-- {
+			line := cart.Line{
+				Quantity:  decimal.New(rnd.Int63n(50), int32(rnd.Intn(2))),
+				UnitPrice: decimal.New(rnd.Int63n(100000), int32(rnd.Intn(4))),
+				TaxClass:  classes[rnd.Intn(len(classes))],
+			}
+			if rnd.Intn(2) == 0 {
+				line.Discounts = append(line.Discounts, decimal.PercentDiscount("p", decimal.NewPercent(decimal.NewFromInt(rnd.Intn(60)))))
+			}
+			if rnd.Intn(3) == 0 {
+				line.Discounts = append(line.Discounts, decimal.AmountDiscount("a", decimal.New(rnd.Int63n(2000), 2)))
+			}
+			c.Lines = append(c.Lines, line)
+		}
+		if rnd.Intn(2) == 0 {
+			c.Discounts = append(c.Discounts, decimal.PercentDiscount("cart", decimal.NewPercent(decimal.NewFromInt(rnd.Intn(30)))))
+		}
+		if rnd.Intn(2) == 0 {
+			c.Discounts = append(c.Discounts, decimal.AmountDiscount("cart", decimal.New(rnd.Int63n(5000), 2)))
+		}
+		totals, err := c.Calculate()
+		require.NoError(t, err, "At %d", k)
+		reconcile(t, c, totals)
+	}
+}
@@ -0,0 +1,87 @@
+package decimal
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// cashIncrements holds the smallest amounts that can be paid in cash where
+// they differ from the minor unit, such as 0.05 for CHF since there are no
+// 1 or 2 centime coins.
+var cashIncrements = struct {
+	mu sync.RWMutex
+	m  map[string]Decimal
+}{m: map[string]Decimal{
+	"AUD": MustNewFromString("0.05"),
+	"CAD": MustNewFromString("0.05"),
+	"CHF": MustNewFromString("0.05"),
+	"CZK": MustNewFromString("1"),
+	"DKK": MustNewFromString("0.50"),
+	"HUF": MustNewFromString("5"),
+	"NOK": MustNewFromString("1"),
+	"NZD": MustNewFromString("0.10"),
+	"SEK": MustNewFromString("1"),
+	"ZAR": MustNewFromString("0.10"),
+}}
+
+// CashIncrement returns the cash increment of the currency with the code. It
+// returns false if cash amounts are rounded to the minor unit of the currency.
+func CashIncrement(code string) (Decimal, bool) {
+	cashIncrements.mu.RLock()
+	defer cashIncrements.mu.RUnlock()
+	inc, ok := cashIncrements.m[strings.ToUpper(strings.TrimSpace(code))]
+	if !ok {
+		return Decimal{}, false
+	}
+	return NewFromDecimal(inc), true
+}
+
+// SetCashIncrement sets the cash increment of a registered currency, such as
+// 25 for a point system that only grants multiples of 25 points.
+func SetCashIncrement(code string, increment Decimal) error {
+	c, ok := LookupCurrency(code)
+	if !ok {
+		return fmt.Errorf("Unknown currency `%s'", code)
+	}
+	if !increment.native().IsFinite() || increment.Cmp(Zero()) <= 0 {
+		return fmt.Errorf("Invalid cash increment %s for currency %s", increment, c.Code)
+	}
+	cashIncrements.mu.Lock()
+	defer cashIncrements.mu.Unlock()
+	cashIncrements.m[c.Code] = NewFromDecimal(increment)
+	return nil
+}
+
+// RoundToIncrement returns m rounded to the nearest multiple of increment with mode
+func (m Money) RoundToIncrement(increment Decimal, mode RoundingMode) Money {
+	return Money{amount: RoundToIncrement(m.amount, increment, mode), currency: m.currency}
+}
+
+// RoundToCash returns m rounded to the cash increment of its currency with
+// ToNearestAway, which is how cash registers round, e.g. 1.025 CHF to 1.05 CHF.
+// Currencies without a cash increment are rounded to their minor unit.
+func (m Money) RoundToCash() Money {
+	return m.RoundToCashMode(ToNearestAway)
+}
+
+// RoundToCashMode returns m rounded to the cash increment of its currency with mode
+func (m Money) RoundToCashMode(mode RoundingMode) Money {
+	if inc, ok := CashIncrement(m.currency); ok {
+		return m.RoundToIncrement(inc, mode)
+	}
+	return m.RoundToMinorUnitsMode(mode)
+}
+
+// IsCashAmount returns true if m can be paid in cash, that is it is a multiple
+// of the cash increment or the minor unit of its currency.
+func (m Money) IsCashAmount() bool {
+	if inc, ok := CashIncrement(m.currency); ok {
+		return m.amount.IsMultipleOf(inc)
+	}
+	units := m.MinorUnits()
+	if units == NoMinorUnits {
+		return true
+	}
+	return m.amount.IsMultipleOf(New(1, int32(units)))
+}
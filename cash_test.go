@@ -0,0 +1,61 @@
+package decimal
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCashIncrement(t *testing.T) {
+	inc, ok := CashIncrement("chf")
+	require.True(t, ok)
+	require.Equal(t, "0.05", inc.String())
+
+	_, ok = CashIncrement("EUR")
+	require.False(t, ok)
+
+	require.EqualError(t, SetCashIncrement("XYZ", NewFromInt(1)), "Unknown currency `XYZ'")
+	require.EqualError(t, SetCashIncrement("EUR", Zero()), "Invalid cash increment 0 for currency EUR")
+	require.EqualError(t, SetCashIncrement("EUR", NewFromInt(-1)), "Invalid cash increment -1 for currency EUR")
+}
+
+func TestMoneyRoundToCash(t *testing.T) {
+	testData := []struct {
+		input    string
+		expected string
+	}{
+		{input: "1.02 CHF", expected: "1.00 CHF"},
+		{input: "1.025 CHF", expected: "1.05 CHF"},
+		{input: "1.074 CHF", expected: "1.05 CHF"},
+		{input: "-1.025 CHF", expected: "-1.05 CHF"},
+		{input: "7.14 NZD", expected: "7.10 NZD"},
+		{input: "99.50 SEK", expected: "100 SEK"},
+		{input: "12.25 DKK", expected: "12.50 DKK"},
+		{input: "1237 HUF", expected: "1235 HUF"},
+		{input: "12.345 EUR", expected: "12.35 EUR"},
+		{input: "12.5 JPY", expected: "13 JPY"},
+	}
+	for i, j := range testData {
+		require.Equal(t, j.expected, MustParseMoney(j.input).RoundToCash().String(), "At %d", i)
+	}
+	require.Equal(t, "1.00 CHF", MustParseMoney("1.025 CHF").RoundToCashMode(ToNearestEven).String())
+	require.Equal(t, "1.05 CHF", MustParseMoney("1.001 CHF").RoundToCashMode(ToPositiveInf).String())
+	require.Equal(t, "0.75 USD", MustParseMoney("0.80 USD").RoundToIncrement(MustNewFromString("0.25"), ToNearestEven).String())
+
+	require.True(t, MustParseMoney("1.05 CHF").IsCashAmount())
+	require.False(t, MustParseMoney("1.01 CHF").IsCashAmount())
+	require.True(t, MustParseMoney("1.01 EUR").IsCashAmount())
+	require.False(t, MustParseMoney("1.001 EUR").IsCashAmount())
+	require.True(t, MustParseMoney("1.23456 XAU").IsCashAmount())
+}
+
+func TestCustomCashIncrement(t *testing.T) {
+	require.NoError(t, registerTestCurrency(t, Currency{Code: "CASHPTS", MinorUnits: 0, Name: "Cash points"}))
+	require.NoError(t, SetCashIncrement("cashpts", NewFromInt(25)))
+
+	require.Equal(t, "100 CASHPTS", MustParseMoney("112 CASHPTS").RoundToCash().String())
+	require.Equal(t, "125 CASHPTS", MustParseMoney("112.5 CASHPTS").RoundToCash().String())
+	require.Equal(t, "100 CASHPTS", MustParseMoney("124 CASHPTS").RoundToCashMode(ToZero).String())
+	require.True(t, MustParseMoney("75 CASHPTS").IsCashAmount())
+	require.False(t, MustParseMoney("80 CASHPTS").IsCashAmount())
+}
@@ -0,0 +1,153 @@
+package decimal
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// CompactStyle selects the suffixes of compact numbers
+type CompactStyle int
+
+const (
+	// CompactShort uses the short scale suffixes K, M, B and T for thousands,
+	// millions, billions and trillions, numbers below a thousand have no suffix
+	CompactShort CompactStyle = iota
+	// CompactSI uses the SI prefixes from a (10^-18) to E (10^18), such as k,
+	// M, G, m and µ
+	CompactSI
+)
+
+// CompactOptions controls FormatCompact
+type CompactOptions struct {
+	// Style selects the suffixes, CompactShort by default
+	Style CompactStyle
+	// Digits is the maximum number of significant digits, 3 by default
+	Digits int
+	// Mode rounds to the significant digits, ToNearestEven by default
+	Mode RoundingMode
+}
+
+// compactSuffix is the suffix of a power of ten, a multiple of three
+type compactSuffix struct {
+	exp    int
+	suffix string
+}
+
+var (
+	shortSuffixes = []compactSuffix{{0, ""}, {3, "K"}, {6, "M"}, {9, "B"}, {12, "T"}}
+	siSuffixes    = []compactSuffix{{-18, "a"}, {-15, "f"}, {-12, "p"}, {-9, "n"}, {-6, "µ"}, {-3, "m"},
+		{0, ""}, {3, "k"}, {6, "M"}, {9, "G"}, {12, "T"}, {15, "P"}, {18, "E"}}
+)
+
+func (s CompactStyle) suffixes() []compactSuffix {
+	if s == CompactSI {
+		return siSuffixes
+	}
+	return shortSuffixes
+}
+
+// FormatCompact returns d rounded to significant digits with the suffix of
+// its magnitude, such as "12.3K" for 12345 or "4.7µ" for 0.0000047. Trailing
+// fractional zeros are dropped, so 12000 is "12K". A value that rounds up to
+// the next magnitude takes its suffix, 999999 is "1M" rather than "1000K".
+// There is no suffix beyond the largest one, T or E, so values of a thousand
+// or more of it keep all their integer digits: 999999999999999 is "1000T"
+// and 1234567890123456 is "1235T". NaN and Infinity are formatted like String.
+func FormatCompact(d Decimal, opts CompactOptions) string {
+	x := d.native()
+	if !x.IsFinite() {
+		return d.String()
+	}
+	if x.Sign() == 0 {
+		return "0"
+	}
+	digits := opts.Digits
+	if digits <= 0 {
+		digits = 3
+	}
+	suffixes := opts.Style.suffixes()
+
+	coefficient, exp := coefficient(d)
+	// magnitude is the exponent of d in scientific notation
+	magnitude := exp + len(coefficient) - 1
+	for {
+		i := 0
+		for i+1 < len(suffixes) && suffixes[i+1].exp <= magnitude {
+			i++
+		}
+		unit := suffixes[i]
+		// d shifted by the exponent of the suffix, exactly
+		shifted := NewFromDecimal(d)
+		shifted.native().SetScale(x.Scale() + unit.exp)
+		places := digits - 1 - (magnitude - unit.exp)
+		last := i+1 == len(suffixes)
+		if last && places < 0 {
+			// beyond the largest suffix, round no integer digits away
+			places = 0
+		}
+		s := shifted.StringFixedMode(places, opts.Mode)
+		if !last && integerDigits(s) >= suffixes[i+1].exp-unit.exp+1 {
+			// rounded up to the next suffix, 999.6K is 1M
+			magnitude = suffixes[i+1].exp
+			continue
+		}
+		if strings.Contains(s, ".") {
+			s = strings.TrimRight(strings.TrimRight(s, "0"), ".")
+		}
+		return s + unit.suffix
+	}
+}
+
+// integerDigits returns the number of integer digits of the fixed point number s
+func integerDigits(s string) int {
+	s = strings.TrimPrefix(s, "-")
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		s = s[:i]
+	}
+	return len(s)
+}
+
+// ParseCompact parses a number with an optional suffix of style, such as
+// "2.5k" or "1.2M", exactly. The suffixes of CompactShort are case
+// insensitive, those of CompactSI are not, though K is accepted for k and u
+// and the Greek μ for µ. The number is parsed with the DefaultParser, special
+// values are not accepted.
+func ParseCompact(s string, style CompactStyle) (Decimal, error) {
+	t := strings.TrimSpace(s)
+	r, size := utf8.DecodeLastRuneInString(t)
+	exp := 0
+	if t != "" && !(r >= '0' && r <= '9' || r == '.') {
+		suffix := string(r)
+		switch {
+		case style == CompactShort:
+			suffix = strings.ToUpper(suffix)
+		case suffix == "K":
+			suffix = "k"
+		case suffix == "u", suffix == "μ":
+			suffix = "µ"
+		}
+		found := false
+		for _, unit := range style.suffixes() {
+			if unit.suffix != "" && unit.suffix == suffix {
+				exp, found = unit.exp, true
+				break
+			}
+		}
+		if !found {
+			return Decimal{}, fmt.Errorf("Invalid compact number `%s': unknown suffix %q", s, string(r))
+		}
+		t = strings.TrimSpace(t[:len(t)-size])
+	}
+	d, err := DefaultParser().Parse(t)
+	if err != nil {
+		return Decimal{}, fmt.Errorf("Invalid compact number `%s'", s)
+	}
+	if !d.native().IsFinite() {
+		return Decimal{}, fmt.Errorf("Invalid compact number `%s'", s)
+	}
+	if exp != 0 {
+		d.native().SetScale(d.native().Scale() - exp)
+	}
+	return d, nil
+}
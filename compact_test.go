@@ -0,0 +1,127 @@
+package decimal_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/talon-one/decimal"
+)
+
+func TestFormatCompact(t *testing.T) {
+	si := decimal.CompactOptions{Style: decimal.CompactSI}
+	testData := []struct {
+		value    string
+		opts     decimal.CompactOptions
+		expected string
+	}{
+		{value: "0", expected: "0"},
+		{value: "-0.00", expected: "0"},
+		{value: "7", expected: "7"},
+		{value: "999", expected: "999"},
+		{value: "999.5", expected: "1K"},
+		{value: "1000", expected: "1K"},
+		{value: "12345", expected: "12.3K"},
+		{value: "-12345", expected: "-12.3K"},
+		{value: "12000", expected: "12K"},
+		{value: "12345", opts: decimal.CompactOptions{Digits: 2}, expected: "12K"},
+		{value: "12345", opts: decimal.CompactOptions{Digits: 5}, expected: "12.345K"},
+		{value: "12345", opts: decimal.CompactOptions{Digits: 8}, expected: "12.345K"},
+		{value: "12355", opts: decimal.CompactOptions{Mode: decimal.ToZero}, expected: "12.3K"},
+		{value: "12350", expected: "12.4K"},
+		{value: "12250", expected: "12.2K"},
+		{value: "12250", opts: decimal.CompactOptions{Mode: decimal.ToNearestAway}, expected: "12.3K"},
+		{value: "999999", expected: "1M"},
+		{value: "1234567", expected: "1.23M"},
+		{value: "1.5E+9", expected: "1.5B"},
+		{value: "2.5E+12", expected: "2.5T"},
+		{value: "999499999999999", expected: "999T"},
+		{value: "999999999999999", expected: "1000T"},
+		{value: "1234567890123456", expected: "1235T"},
+		{value: "-1234567890123456.7", expected: "-1235T"},
+		{value: "1.2345E+17", expected: "123450T"},
+		{value: "1.2345E+17", opts: si, expected: "123P"},
+		{value: "999999999999999999999", opts: si, expected: "1000E"},
+		{value: "1.2345678E+21", opts: si, expected: "1235E"},
+		{value: "9.995E+20", opts: si, expected: "1000E"},
+		{value: "0.5", expected: "0.5"},
+		{value: "0.0012345", expected: "0.00123"},
+		{value: "12345", opts: si, expected: "12.3k"},
+		{value: "1.5E+9", opts: si, expected: "1.5G"},
+		{value: "0.0012345", opts: si, expected: "1.23m"},
+		{value: "0.0000047", opts: si, expected: "4.7µ"},
+		{value: "0.000999999", opts: si, expected: "1m"},
+		{value: "3E-20", opts: si, expected: "0.03a"},
+		{value: "Infinity", expected: "Infinity"},
+	}
+	data := setup(func() []string {
+		values := make([]string, len(testData))
+		for i := range testData {
+			values[i] = testData[i].value
+		}
+		return values
+	}()...)
+	for i, j := range testData {
+		require.Equal(t, j.expected, decimal.FormatCompact(data.Decimals[i], j.opts), "At %d", i)
+	}
+	data.VerifyIntegrity(t)
+}
+
+func TestParseCompact(t *testing.T) {
+	testData := []struct {
+		input    string
+		style    decimal.CompactStyle
+		expected string
+	}{
+		{input: "2.5k", expected: "2500"},
+		{input: "2.5K", expected: "2500"},
+		{input: "1.2M", expected: "1200000"},
+		{input: "1.2m", expected: "1200000"},
+		{input: "-3B", expected: "-3000000000"},
+		{input: " 4.25 T ", expected: "4250000000000"},
+		{input: "12.345", expected: "12.345"},
+		{input: "0.001K", expected: "1"},
+		{input: "2.5k", style: decimal.CompactSI, expected: "2500"},
+		{input: "2.5K", style: decimal.CompactSI, expected: "2500"},
+		{input: "1.5m", style: decimal.CompactSI, expected: "0.0015"},
+		{input: "1.5M", style: decimal.CompactSI, expected: "1500000"},
+		{input: "4.7µ", style: decimal.CompactSI, expected: "0.0000047"},
+		{input: "4.7μ", style: decimal.CompactSI, expected: "0.0000047"},
+		{input: "4.7u", style: decimal.CompactSI, expected: "0.0000047"},
+		{input: "2G", style: decimal.CompactSI, expected: "2000000000"},
+	}
+	for i, j := range testData {
+		d, err := decimal.ParseCompact(j.input, j.style)
+		require.NoError(t, err, "At %d", i)
+		require.Equal(t, j.expected, d.String(), "At %d", i)
+	}
+
+	for i, j := range []struct {
+		input string
+		style decimal.CompactStyle
+		err   string
+	}{
+		{input: "", err: "Invalid compact number `'"},
+		{input: "K", err: "Invalid compact number `K'"},
+		{input: "2.5x", err: "Invalid compact number `2.5x': unknown suffix \"x\""},
+		{input: "2G", err: "Invalid compact number `2G': unknown suffix \"G\""},
+		{input: "2B", style: decimal.CompactSI, err: "Invalid compact number `2B': unknown suffix \"B\""},
+		{input: "2kk", err: "Invalid compact number `2kk'"},
+		{input: "Inf", err: "Invalid compact number `Inf': unknown suffix \"f\""},
+		{input: "Inf", style: decimal.CompactSI, err: "Invalid compact number `Inf'"},
+		{input: "InfK", err: "Invalid compact number `InfK'"},
+	} {
+		_, err := decimal.ParseCompact(j.input, j.style)
+		require.EqualError(t, err, j.err, "At %d", i)
+	}
+}
+
+func TestCompactRoundTrip(t *testing.T) {
+	for _, style := range []decimal.CompactStyle{decimal.CompactShort, decimal.CompactSI} {
+		for i, v := range []string{"1", "12.3", "4560", "78900000", "-1.23E+12", "0.25"} {
+			d := decimal.MustNewFromString(v)
+			parsed, err := decimal.ParseCompact(decimal.FormatCompact(d, decimal.CompactOptions{Style: style}), style)
+			require.NoError(t, err, "At %d", i)
+			require.Zero(t, d.Cmp(parsed), "At %d: %s != %s", i, d, parsed)
+		}
+	}
+}
@@ -0,0 +1,151 @@
+package decimal
+
+import (
+	_ "embed"
+	"encoding/csv"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// iso4217 lists every current and historic ISO 4217 currency as
+// code,numeric,minor_units,name,withdrawn
+//
+//go:embed iso4217.csv
+var iso4217 string
+
+// NoMinorUnits is the MinorUnits value of currencies without a minor unit, such as gold (XAU)
+const NoMinorUnits = -1
+
+// Currency describes a currency of the registry
+type Currency struct {
+	// Code is the alphabetic code, such as "EUR"
+	Code string
+	// Numeric is the ISO 4217 numeric code, such as 978. It is 0 if there is none.
+	Numeric int
+	// MinorUnits is the number of decimal places of the minor unit, such as
+	// 2 for EUR (cents) or 0 for JPY. It is NoMinorUnits if not applicable.
+	MinorUnits int
+	// Name is the English name of the currency
+	Name string
+	// Withdrawn is the date a historic currency was withdrawn as "YYYY-MM",
+	// or "unknown". It is empty for currencies in use.
+	Withdrawn string
+	// Custom is true for currencies added with RegisterCurrency
+	Custom bool
+}
+
+// IsHistoric returns true if the currency has been withdrawn
+func (c Currency) IsHistoric() bool {
+	return c.Withdrawn != ""
+}
+
+type currencyRegistry struct {
+	once      sync.Once
+	mu        sync.RWMutex
+	byCode    map[string]Currency
+	byNumeric map[int]Currency
+}
+
+var currencies currencyRegistry
+
+func (r *currencyRegistry) load() {
+	r.once.Do(func() {
+		records, err := csv.NewReader(strings.NewReader(iso4217)).ReadAll()
+		if err != nil {
+			panic(fmt.Sprintf("decimal: invalid embedded currency table: %v", err))
+		}
+		r.byCode = make(map[string]Currency, len(records))
+		r.byNumeric = make(map[int]Currency, len(records))
+		for _, record := range records[1:] {
+			c := Currency{Code: record[0], Name: record[3], Withdrawn: record[4]}
+			if record[1] != "" {
+				if c.Numeric, err = strconv.Atoi(record[1]); err != nil {
+					panic(fmt.Sprintf("decimal: invalid numeric code for %s: %v", c.Code, err))
+				}
+			}
+			if c.MinorUnits, err = strconv.Atoi(record[2]); err != nil {
+				panic(fmt.Sprintf("decimal: invalid minor units for %s: %v", c.Code, err))
+			}
+			r.add(c)
+		}
+	})
+}
+
+// add must be called with the write lock held or during load
+func (r *currencyRegistry) add(c Currency) {
+	r.byCode[c.Code] = c
+	if c.Numeric == 0 {
+		return
+	}
+	// numeric codes of withdrawn currencies are reused, the current one wins
+	if existing, ok := r.byNumeric[c.Numeric]; !ok || existing.IsHistoric() {
+		r.byNumeric[c.Numeric] = c
+	}
+}
+
+// LookupCurrency returns the currency with the alphabetic code, case is ignored
+func LookupCurrency(code string) (Currency, bool) {
+	currencies.load()
+	currencies.mu.RLock()
+	defer currencies.mu.RUnlock()
+	c, ok := currencies.byCode[strings.ToUpper(strings.TrimSpace(code))]
+	return c, ok
+}
+
+// LookupCurrencyNumeric returns the currency with the ISO 4217 numeric code.
+// If a code was reused, the currency in use is returned.
+func LookupCurrencyNumeric(numeric int) (Currency, bool) {
+	currencies.load()
+	currencies.mu.RLock()
+	defer currencies.mu.RUnlock()
+	c, ok := currencies.byNumeric[numeric]
+	return c, ok
+}
+
+// Currencies returns all registered currencies ordered by code
+func Currencies() []Currency {
+	currencies.load()
+	currencies.mu.RLock()
+	list := make([]Currency, 0, len(currencies.byCode))
+	for _, c := range currencies.byCode {
+		list = append(list, c)
+	}
+	currencies.mu.RUnlock()
+	sort.Slice(list, func(i, j int) bool { return list[i].Code < list[j].Code })
+	return list
+}
+
+// RegisterCurrency adds a custom currency, such as loyalty points or store
+// credit, to the registry. The code must consist of 3 to 12 letters or digits
+// and must not be taken, the numeric code is optional. MinorUnits may be any
+// scale including 0.
+func RegisterCurrency(c Currency) error {
+	c.Code = strings.ToUpper(strings.TrimSpace(c.Code))
+	if len(c.Code) < 3 || len(c.Code) > 12 {
+		return fmt.Errorf("Invalid currency code `%s': must have 3 to 12 characters", c.Code)
+	}
+	for _, r := range c.Code {
+		if (r < 'A' || r > 'Z') && (r < '0' || r > '9') {
+			return fmt.Errorf("Invalid currency code `%s': must consist of letters and digits", c.Code)
+		}
+	}
+	if c.MinorUnits < NoMinorUnits {
+		return fmt.Errorf("Invalid minor units %d for currency %s", c.MinorUnits, c.Code)
+	}
+	c.Custom = true
+
+	currencies.load()
+	currencies.mu.Lock()
+	defer currencies.mu.Unlock()
+	if _, ok := currencies.byCode[c.Code]; ok {
+		return fmt.Errorf("Currency %s is already registered", c.Code)
+	}
+	if existing, ok := currencies.byNumeric[c.Numeric]; ok && c.Numeric != 0 {
+		return fmt.Errorf("Numeric code %d is already used by %s", c.Numeric, existing.Code)
+	}
+	currencies.add(c)
+	return nil
+}
@@ -0,0 +1,139 @@
+package decimal
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// registerTestCurrency registers c and removes it and its cash increment when
+// the test ends, so the tests can run repeatedly in one process
+func registerTestCurrency(t *testing.T, c Currency) error {
+	err := RegisterCurrency(c)
+	if err == nil {
+		code := strings.ToUpper(strings.TrimSpace(c.Code))
+		t.Cleanup(func() { unregisterCurrency(code) })
+	}
+	return err
+}
+
+func unregisterCurrency(code string) {
+	currencies.mu.Lock()
+	c := currencies.byCode[code]
+	delete(currencies.byCode, code)
+	if existing, ok := currencies.byNumeric[c.Numeric]; ok && existing.Code == code {
+		delete(currencies.byNumeric, c.Numeric)
+	}
+	currencies.mu.Unlock()
+
+	cashIncrements.mu.Lock()
+	delete(cashIncrements.m, code)
+	cashIncrements.mu.Unlock()
+}
+
+func TestLookupCurrency(t *testing.T) {
+	testData := []struct {
+		code       string
+		numeric    int
+		minorUnits int
+		name       string
+	}{
+		{code: "EUR", numeric: 978, minorUnits: 2, name: "Euro"},
+		{code: "usd", numeric: 840, minorUnits: 2, name: "US Dollar"},
+		{code: "JPY", numeric: 392, minorUnits: 0, name: "Yen"},
+		{code: "KWD", numeric: 414, minorUnits: 3, name: "Kuwaiti Dinar"},
+		{code: "CLF", numeric: 990, minorUnits: 4, name: "Unidad de Fomento"},
+		{code: "XAU", numeric: 959, minorUnits: NoMinorUnits, name: "Gold"},
+	}
+	for i, j := range testData {
+		c, ok := LookupCurrency(j.code)
+		require.True(t, ok, "At %d", i)
+		require.Equal(t, j.numeric, c.Numeric, "At %d", i)
+		require.Equal(t, j.minorUnits, c.MinorUnits, "At %d", i)
+		require.Equal(t, j.name, c.Name, "At %d", i)
+		require.False(t, c.IsHistoric(), "At %d", i)
+		require.False(t, c.Custom, "At %d", i)
+
+		byNumeric, ok := LookupCurrencyNumeric(j.numeric)
+		require.True(t, ok, "At %d", i)
+		require.Equal(t, c, byNumeric, "At %d", i)
+	}
+
+	_, ok := LookupCurrency("ABC")
+	require.False(t, ok)
+	_, ok = LookupCurrencyNumeric(1)
+	require.False(t, ok)
+}
+
+func TestHistoricCurrency(t *testing.T) {
+	c, ok := LookupCurrency("DEM")
+	require.True(t, ok)
+	require.True(t, c.IsHistoric())
+	require.Equal(t, "2002-03", c.Withdrawn)
+
+	// the numeric code of a historic currency that was reused resolves to the current one
+	c, ok = LookupCurrencyNumeric(807)
+	require.True(t, ok)
+	require.Equal(t, "MKD", c.Code)
+
+	m, err := NewMoney(NewFromInt(10), "DEM")
+	require.NoError(t, err)
+	require.True(t, m.CurrencyInfo().IsHistoric())
+}
+
+func TestRegisterCurrency(t *testing.T) {
+	require.NoError(t, registerTestCurrency(t, Currency{Code: "points", MinorUnits: 0, Name: "Loyalty points"}))
+	c, ok := LookupCurrency("POINTS")
+	require.True(t, ok)
+	require.True(t, c.Custom)
+	require.Equal(t, "Loyalty points", c.Name)
+
+	m := MustParseMoney("12.6 POINTS")
+	require.Equal(t, "13 POINTS", m.RoundToMinorUnits().String())
+
+	require.NoError(t, registerTestCurrency(t, Currency{Code: "TOK", Numeric: 7001, MinorUnits: 8, Name: "Token"}))
+	c, ok = LookupCurrencyNumeric(7001)
+	require.True(t, ok)
+	require.Equal(t, "TOK", c.Code)
+	require.Equal(t, "0.12345679 TOK", MustParseMoney("0.123456789 TOK").RoundToMinorUnits().String())
+
+	require.EqualError(t, RegisterCurrency(Currency{Code: "EUR", MinorUnits: 2}), "Currency EUR is already registered")
+	require.EqualError(t, RegisterCurrency(Currency{Code: "POINTS", MinorUnits: 2}), "Currency POINTS is already registered")
+	require.EqualError(t, RegisterCurrency(Currency{Code: "EURO", Numeric: 978}), "Numeric code 978 is already used by EUR")
+	require.EqualError(t, RegisterCurrency(Currency{Code: "AB"}), "Invalid currency code `AB': must have 3 to 12 characters")
+	require.EqualError(t, RegisterCurrency(Currency{Code: "STORE-CREDIT"}), "Invalid currency code `STORE-CREDIT': must consist of letters and digits")
+	require.EqualError(t, RegisterCurrency(Currency{Code: "NEG", MinorUnits: -2}), "Invalid minor units -2 for currency NEG")
+}
+
+func TestCurrenciesConcurrency(t *testing.T) {
+	var wg sync.WaitGroup
+	errs := make([]error, 8)
+	found := make([]bool, 8)
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs[i] = registerTestCurrency(t, Currency{Code: fmt.Sprintf("CONC%d", i), MinorUnits: i})
+			found[i] = true
+			for k := 0; k < 100; k++ {
+				_, ok := LookupCurrency("EUR")
+				_, ok2 := LookupCurrency(fmt.Sprintf("CONC%d", i))
+				found[i] = found[i] && ok && ok2
+			}
+		}(i)
+	}
+	wg.Wait()
+	for i := range errs {
+		require.NoError(t, errs[i], "At %d", i)
+		require.True(t, found[i], "At %d", i)
+	}
+
+	list := Currencies()
+	require.True(t, len(list) > 250)
+	for i := 1; i < len(list); i++ {
+		require.True(t, list[i-1].Code < list[i].Code)
+	}
+}
@@ -3,6 +3,7 @@ package decimal
 import (
 	"errors"
 	"fmt"
+	"math/big"
 
 	"github.com/ericlagergren/decimal"
 )
@@ -359,20 +360,31 @@ func (d Decimal) MustFloat64() float64 {
 	return v
 }
 
-func (d Decimal) String() string {
-	if d.native() == nil || d.Equals(zero) {
-		return "0"
+// Rat returns d as an exact fraction, it fails for NaN and Infinity
+func (d Decimal) Rat() (*big.Rat, error) {
+	if !d.native().IsFinite() {
+		return nil, fmt.Errorf("`%s' not a rational number", d.String())
 	}
-	return fmt.Sprintf("%f", d)
+	return d.native().Rat(nil), nil
 }
 
-func (d Decimal) Bytes() []byte {
-	return []byte(d.String())
+func (d Decimal) MustRat() *big.Rat {
+	v, err := d.Rat()
+	if err != nil {
+		panic(err)
+	}
+	return v
 }
 
-// Format implements the fmt.Formatter interface.
-func (d Decimal) Format(s fmt.State, c rune) {
-	d.native().Format(s, c)
+// String returns d without exponent, such as "1000000" for 1E+6. Zeros are
+// "0" regardless of their scale.
+func (d Decimal) String() string {
+	var buf [64]byte
+	return string(d.AppendText(buf[:0]))
+}
+
+func (d Decimal) Bytes() []byte {
+	return d.AppendText(nil)
 }
 
 // IsNaN is a method that wraps IsNaN method of the Big type. Big type method expects an integer argument called quiet. Here's a breakdown of the input values and what they mean:
@@ -99,6 +99,30 @@ func TestNewFromInterface(t *testing.T) {
 	require.EqualError(t, err, "Unable to create decimal from value type *decimal.testStruct: Invalid decimal")
 }
 
+func TestRat(t *testing.T) {
+	testData := []struct {
+		input  string
+		output string
+	}{
+		{"1.50", "3/2"},
+		{"-0.125", "-1/8"},
+		{"1E+20", "100000000000000000000/1"},
+		{"0.000000000000000000000000000001", "1/1000000000000000000000000000000"},
+		{"0.00", "0/1"},
+	}
+	for i, test := range testData {
+		r, err := MustNewFromString(test.input).Rat()
+		require.NoError(t, err, "At %d", i)
+		require.Equal(t, test.output, r.String(), "At %d", i)
+	}
+
+	inf := MustNewFromString("Infinity")
+	for i, d := range []Decimal{inf, MustNewFromString("-Infinity"), inf.Mul(NewFromInt(0))} {
+		_, err := d.Rat()
+		require.EqualError(t, err, "`"+d.String()+"' not a rational number", "At %d", i)
+	}
+}
+
 func TestNilDecimal(t *testing.T) {
 	var d Decimal
 	require.Equal(t, "0", d.String())
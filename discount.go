@@ -0,0 +1,162 @@
+package decimal
+
+import (
+	"math/big"
+	"sort"
+)
+
+// Discount is a percentage or amount taken off a price. The effective discount
+// is never negative, never exceeds the optional cap and never reduces the
+// price below the optional floor or below zero.
+type Discount struct {
+	// Name identifies the discount in a StackResult
+	Name      string
+	percent   Percent
+	amount    Decimal
+	isPercent bool
+	cap       *Decimal
+	floor     *Decimal
+}
+
+// PercentDiscount returns a discount of p of the price
+func PercentDiscount(name string, p Percent) Discount {
+	return Discount{Name: name, percent: p, isPercent: true}
+}
+
+// AmountDiscount returns a discount of a fixed amount
+func AmountDiscount(name string, amount Decimal) Discount {
+	return Discount{Name: name, amount: NewFromDecimal(amount)}
+}
+
+// WithCap returns d limited to a discount of at most maxDiscount
+func (d Discount) WithCap(maxDiscount Decimal) Discount {
+	c := NewFromDecimal(maxDiscount)
+	d.cap = &c
+	return d
+}
+
+// WithFloor returns d limited so the price does not drop below minPrice
+func (d Discount) WithFloor(minPrice Decimal) Discount {
+	f := NewFromDecimal(minPrice)
+	d.floor = &f
+	return d
+}
+
+// DiscountResult is a discount applied to a price, Price - Discount == Result
+type DiscountResult struct {
+	Name     string
+	Price    Decimal
+	Discount Decimal
+	Result   Decimal
+	// Capped is set if the cap of the discount reduced it
+	Capped bool
+	// Floored is set if the discount was reduced so the result does not drop
+	// below the floor of the discount or below zero
+	Floored bool
+}
+
+// Apply applies d to price. The discount is rounded to the scale, digits, with
+// mode before it is limited by the cap and the floor.
+func (d Discount) Apply(price Decimal, digits int, mode RoundingMode) DiscountResult {
+	r := DiscountResult{Name: d.Name, Price: NewFromDecimal(price)}
+	var discount Decimal
+	if d.isPercent {
+		exact := new(big.Rat).Mul(price.native().Rat(nil), d.percent.ratio.native().Rat(nil))
+		discount = newFromRat(exact, digits, mode)
+	} else {
+		discount = QuantizeMode(d.amount, digits, mode)
+	}
+	if discount.Cmp(Zero()) < 0 {
+		discount = QuantizeMode(Zero(), digits, mode)
+	}
+	if d.cap != nil && discount.Cmp(*d.cap) > 0 {
+		discount = QuantizeMode(Max(*d.cap, Zero()), digits, ToZero)
+		r.Capped = true
+	}
+	floor := Zero()
+	if d.floor != nil && d.floor.Cmp(floor) > 0 {
+		floor = *d.floor
+	}
+	room := QuantizeMode(Max(Sub(price, floor), Zero()), digits, ToZero)
+	if discount.Cmp(room) > 0 {
+		discount = room
+		r.Floored = true
+	}
+	r.Discount = discount
+	r.Result = Sub(price, discount)
+	return r
+}
+
+// ApplyPercentOff applies a discount of p of price that does not exceed
+// maxDiscount, if it is not nil, or price. See Discount.Apply.
+func ApplyPercentOff(price Decimal, p Percent, maxDiscount *Decimal, digits int, mode RoundingMode) DiscountResult {
+	d := PercentDiscount("", p)
+	if maxDiscount != nil {
+		d = d.WithCap(*maxDiscount)
+	}
+	return d.Apply(price, digits, mode)
+}
+
+// ApplyAmountOff applies a discount of amount that does not exceed price.
+// See Discount.Apply.
+func ApplyAmountOff(price Decimal, amount Decimal, digits int, mode RoundingMode) DiscountResult {
+	return AmountDiscount("", amount).Apply(price, digits, mode)
+}
+
+// StackOrder determines the order in which StackDiscounts applies discounts
+type StackOrder int
+
+const (
+	// InGivenOrder applies the discounts in the order they are passed
+	InGivenOrder StackOrder = iota
+	// AmountsFirst applies amount discounts before percentage discounts,
+	// which makes the percentages worth less
+	AmountsFirst
+	// PercentagesFirst applies percentage discounts before amount discounts
+	PercentagesFirst
+	// LargestFirst applies the discounts in the order of their value on the
+	// undiscounted price, the largest first
+	LargestFirst
+)
+
+// StackResult is the result of StackDiscounts, Price - Discount == Result
+type StackResult struct {
+	Price    Decimal
+	Discount Decimal
+	Result   Decimal
+	// Steps lists the applied discounts in the order they were applied
+	Steps []DiscountResult
+}
+
+// StackDiscounts applies the discounts one after the other in order, every
+// discount applies to the price left by the previous ones. Discounts in the
+// same position keep their relative order.
+func StackDiscounts(price Decimal, discounts []Discount, order StackOrder, digits int, mode RoundingMode) StackResult {
+	sorted := make([]Discount, len(discounts))
+	copy(sorted, discounts)
+	switch order {
+	case AmountsFirst:
+		sort.SliceStable(sorted, func(i, j int) bool { return !sorted[i].isPercent && sorted[j].isPercent })
+	case PercentagesFirst:
+		sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].isPercent && !sorted[j].isPercent })
+	case LargestFirst:
+		values := make([]Decimal, len(sorted))
+		index := make([]int, len(sorted))
+		for i, d := range sorted {
+			index[i] = i
+			values[i] = d.Apply(price, digits, mode).Discount
+		}
+		sort.SliceStable(index, func(i, j int) bool { return values[index[i]].Cmp(values[index[j]]) > 0 })
+		for i, k := range index {
+			sorted[i] = discounts[k]
+		}
+	}
+
+	r := StackResult{Price: NewFromDecimal(price), Result: NewFromDecimal(price), Steps: make([]DiscountResult, len(sorted))}
+	for i, d := range sorted {
+		r.Steps[i] = d.Apply(r.Result, digits, mode)
+		r.Result = r.Steps[i].Result
+	}
+	r.Discount = Sub(price, r.Result)
+	return r
+}
@@ -0,0 +1,140 @@
+package decimal_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/talon-one/decimal"
+)
+
+func TestApplyPercentOff(t *testing.T) {
+	cap10 := decimal.NewFromInt(10)
+	testData := []struct {
+		price    string
+		percent  string
+		cap      *decimal.Decimal
+		mode     decimal.RoundingMode
+		discount string
+		result   string
+		capped   bool
+		floored  bool
+	}{
+		{price: "19.99", percent: "15%", discount: "3.00", result: "16.99"},
+		{price: "19.99", percent: "15%", mode: decimal.ToZero, discount: "2.99", result: "17.00"},
+		{price: "100", percent: "25%", cap: &cap10, discount: "10.00", result: "90.00", capped: true},
+		{price: "30", percent: "25%", cap: &cap10, discount: "7.50", result: "22.50"},
+		{price: "50", percent: "150%", discount: "50.00", result: "0", floored: true},
+		{price: "50", percent: "-10%", discount: "0", result: "50.00"},
+		{price: "0", percent: "10%", discount: "0", result: "0"},
+	}
+	for i, j := range testData {
+		data := setup(j.price)
+		r := decimal.ApplyPercentOff(data.Decimals[0], decimal.MustParsePercent(j.percent), j.cap, 2, j.mode)
+		require.Equal(t, j.discount, r.Discount.String(), "At %d", i)
+		require.Equal(t, j.result, r.Result.String(), "At %d", i)
+		require.Equal(t, j.capped, r.Capped, "At %d", i)
+		require.Equal(t, j.floored, r.Floored, "At %d", i)
+		data.VerifyIntegrity(t)
+	}
+	require.Equal(t, "10", cap10.String())
+}
+
+func TestApplyAmountOff(t *testing.T) {
+	data := setup("12.50", "5", "20", "-1")
+	r := decimal.ApplyAmountOff(data.Decimals[0], data.Decimals[1], 2, decimal.ToNearestEven)
+	require.Equal(t, "5.00", r.Discount.String())
+	require.Equal(t, "7.50", r.Result.String())
+	require.False(t, r.Floored)
+
+	r = decimal.ApplyAmountOff(data.Decimals[0], data.Decimals[2], 2, decimal.ToNearestEven)
+	require.Equal(t, "12.50", r.Discount.String())
+	require.Equal(t, "0", r.Result.String())
+	require.True(t, r.Floored)
+
+	r = decimal.ApplyAmountOff(data.Decimals[0], data.Decimals[3], 2, decimal.ToNearestEven)
+	require.Equal(t, "0", r.Discount.String())
+	require.Equal(t, "12.50", r.Result.String())
+	data.VerifyIntegrity(t)
+}
+
+func TestDiscountFloor(t *testing.T) {
+	d := decimal.AmountDiscount("clearance", decimal.NewFromInt(30)).WithFloor(decimal.NewFromInt(80))
+	r := d.Apply(decimal.NewFromInt(100), 2, decimal.ToNearestEven)
+	require.Equal(t, "20.00", r.Discount.String())
+	require.Equal(t, "80.00", r.Result.String())
+	require.True(t, r.Floored)
+
+	r = d.Apply(decimal.NewFromInt(70), 2, decimal.ToNearestEven)
+	require.Equal(t, "0", r.Discount.String())
+	require.True(t, r.Floored)
+}
+
+func TestStackDiscounts(t *testing.T) {
+	tenPercent := decimal.PercentDiscount("10%", decimal.MustParsePercent("10%"))
+	fiveOff := decimal.AmountDiscount("5 off", decimal.NewFromInt(5))
+	capped := decimal.PercentDiscount("50% up to 20", decimal.MustParsePercent("50%")).WithCap(decimal.NewFromInt(20))
+	discounts := []decimal.Discount{tenPercent, fiveOff, capped}
+
+	testData := []struct {
+		order  decimal.StackOrder
+		result string
+		steps  []string
+	}{
+		{order: decimal.InGivenOrder, result: "20.00", steps: []string{"10%", "5 off", "50% up to 20"}},
+		{order: decimal.AmountsFirst, result: "20.50", steps: []string{"5 off", "10%", "50% up to 20"}},
+		{order: decimal.PercentagesFirst, result: "20.00", steps: []string{"10%", "50% up to 20", "5 off"}},
+		{order: decimal.LargestFirst, result: "22.00", steps: []string{"50% up to 20", "10%", "5 off"}},
+	}
+	for i, j := range testData {
+		data := setup("50")
+		r := decimal.StackDiscounts(data.Decimals[0], discounts, j.order, 2, decimal.ToNearestEven)
+		require.Equal(t, j.result, r.Result.String(), "At %d", i)
+		require.True(t, decimal.Sub(r.Price, r.Discount).Equals(r.Result), "At %d", i)
+		var steps []string
+		for _, s := range r.Steps {
+			steps = append(steps, s.Name)
+		}
+		require.Equal(t, j.steps, steps, "At %d", i)
+		data.VerifyIntegrity(t)
+	}
+	require.Equal(t, "10%", discounts[0].Name)
+
+	r := decimal.StackDiscounts(decimal.NewFromInt(50), discounts, decimal.LargestFirst, 2, decimal.ToNearestEven)
+	require.True(t, r.Steps[0].Capped)
+	require.Equal(t, "20.00", r.Steps[0].Discount.String())
+}
+
+func TestDiscountProperties(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for k := 0; k < 2000; k++ {
+		price := decimal.New(rnd.Int63n(1000000), int32(rnd.Intn(4)))
+		digits := rnd.Intn(4)
+		mode := decimal.RoundingMode(rnd.Intn(6))
+		var discounts []decimal.Discount
+		for i := rnd.Intn(5); i >= 0; i-- {
+			var d decimal.Discount
+			if rnd.Intn(2) == 0 {
+				d = decimal.PercentDiscount("p", decimal.NewPercent(decimal.New(rnd.Int63n(25000)-2000, 2)))
+			} else {
+				d = decimal.AmountDiscount("a", decimal.New(rnd.Int63n(500000)-10000, int32(rnd.Intn(4))))
+			}
+			if rnd.Intn(3) == 0 {
+				d = d.WithCap(decimal.New(rnd.Int63n(100000), 2))
+			}
+			if rnd.Intn(4) == 0 {
+				d = d.WithFloor(decimal.New(rnd.Int63n(100000), 2))
+			}
+			discounts = append(discounts, d)
+		}
+		r := decimal.StackDiscounts(price, discounts, decimal.StackOrder(rnd.Intn(4)), digits, mode)
+		require.True(t, r.Result.Cmp(decimal.Zero()) >= 0, "At %d: %s", k, r.Result)
+		require.True(t, r.Result.Cmp(price) <= 0, "At %d: %s > %s", k, r.Result, price)
+		require.True(t, decimal.Sub(r.Price, r.Discount).Equals(r.Result), "At %d", k)
+		for _, s := range r.Steps {
+			require.True(t, s.Discount.Cmp(decimal.Zero()) >= 0, "At %d", k)
+			require.True(t, s.Result.Cmp(decimal.Zero()) >= 0, "At %d", k)
+			require.True(t, decimal.Sub(s.Price, s.Discount).Equals(s.Result), "At %d", k)
+		}
+	}
+}
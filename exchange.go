@@ -0,0 +1,307 @@
+package decimal
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ConversionDigits is the scale of converted amounts in currencies without a minor unit
+const ConversionDigits = 10
+
+// ExchangeRate is the price of one unit of Base in Quote at Timestamp,
+// 1 Base = Rate Quote.
+type ExchangeRate struct {
+	Base      string
+	Quote     string
+	Rate      Decimal
+	Timestamp time.Time
+}
+
+// NewExchangeRate returns the rate of base in quote, both currencies must be
+// registered and the rate must be positive.
+func NewExchangeRate(base, quote string, rate Decimal, timestamp time.Time) (ExchangeRate, error) {
+	b, ok := LookupCurrency(base)
+	if !ok {
+		return ExchangeRate{}, fmt.Errorf("Unknown currency `%s'", base)
+	}
+	q, ok := LookupCurrency(quote)
+	if !ok {
+		return ExchangeRate{}, fmt.Errorf("Unknown currency `%s'", quote)
+	}
+	if !rate.native().IsFinite() || rate.Cmp(Zero()) <= 0 {
+		return ExchangeRate{}, fmt.Errorf("Invalid exchange rate %s for %s/%s", rate, b.Code, q.Code)
+	}
+	return ExchangeRate{Base: b.Code, Quote: q.Code, Rate: NewFromDecimal(rate), Timestamp: timestamp}, nil
+}
+
+func (r ExchangeRate) String() string {
+	return fmt.Sprintf("%s/%s %s", r.Base, r.Quote, r.Rate)
+}
+
+// RateProvider returns exchange rates. Rate returns false if it has no rate
+// for the pair, it is not expected to invert or triangulate rates.
+type RateProvider interface {
+	Rate(base, quote string) (ExchangeRate, bool)
+}
+
+// PivotRateProvider is a RateProvider that quotes its rates against a pivot
+// currency. Convert triangulates through the pivot if a pair has no rate.
+type PivotRateProvider interface {
+	RateProvider
+	Pivot() string
+}
+
+type currencyPair struct {
+	base  string
+	quote string
+}
+
+// StaticRates is an in-memory RateProvider that is safe for concurrent use
+type StaticRates struct {
+	pivot string
+	mu    sync.RWMutex
+	rates map[currencyPair]ExchangeRate
+}
+
+// NewStaticRates returns a provider with rates, pivot may be empty to disable triangulation
+func NewStaticRates(pivot string, rates ...ExchangeRate) *StaticRates {
+	s := &StaticRates{pivot: strings.ToUpper(pivot), rates: make(map[currencyPair]ExchangeRate, len(rates))}
+	for _, r := range rates {
+		s.add(s.rates, r)
+	}
+	return s
+}
+
+// add keeps the most recent rate of a pair
+func (s *StaticRates) add(rates map[currencyPair]ExchangeRate, r ExchangeRate) {
+	pair := currencyPair{base: r.Base, quote: r.Quote}
+	if existing, ok := rates[pair]; ok && existing.Timestamp.After(r.Timestamp) {
+		return
+	}
+	rates[pair] = r
+}
+
+// Set adds r, it replaces an older rate of the same pair
+func (s *StaticRates) Set(r ExchangeRate) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.add(s.rates, r)
+}
+
+// Rate implements the RateProvider interface
+func (s *StaticRates) Rate(base, quote string) (ExchangeRate, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	r, ok := s.rates[currencyPair{base: base, quote: quote}]
+	return r, ok
+}
+
+// Pivot implements the PivotRateProvider interface
+func (s *StaticRates) Pivot() string {
+	return s.pivot
+}
+
+func (s *StaticRates) replace(rates []ExchangeRate) {
+	m := make(map[currencyPair]ExchangeRate, len(rates))
+	for _, r := range rates {
+		s.add(m, r)
+	}
+	s.mu.Lock()
+	s.rates = m
+	s.mu.Unlock()
+}
+
+// CSVRates is a RateProvider backed by a CSV file, see ReadRatesCSV for the format
+type CSVRates struct {
+	*StaticRates
+	path string
+}
+
+// LoadCSVRates reads the rates in the CSV file at path
+func LoadCSVRates(path string, pivot string) (*CSVRates, error) {
+	c := &CSVRates{StaticRates: NewStaticRates(pivot), path: path}
+	if err := c.Reload(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// Reload reads the file again and replaces all rates. On error the current rates are kept.
+func (c *CSVRates) Reload() error {
+	f, err := os.Open(c.path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	rates, err := ReadRatesCSV(f)
+	if err != nil {
+		return fmt.Errorf("%s: %w", c.path, err)
+	}
+	c.replace(rates)
+	return nil
+}
+
+// ReadRatesCSV reads rates with the columns base,quote,rate,timestamp where the
+// timestamp is in RFC 3339 format and may be empty. A header line starting
+// with "base" is skipped.
+func ReadRatesCSV(r io.Reader) ([]ExchangeRate, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	rates := make([]ExchangeRate, 0, len(records))
+	for i, record := range records {
+		if i == 0 && len(record) > 0 && strings.EqualFold(record[0], "base") {
+			continue
+		}
+		if len(record) < 3 || len(record) > 4 {
+			return nil, fmt.Errorf("Invalid exchange rate on line %d: expected base,quote,rate[,timestamp]", i+1)
+		}
+		rate, err := NewFromString(record[2])
+		if err != nil {
+			return nil, fmt.Errorf("Invalid exchange rate on line %d: %v", i+1, err)
+		}
+		var timestamp time.Time
+		if len(record) == 4 && record[3] != "" {
+			if timestamp, err = time.Parse(time.RFC3339, record[3]); err != nil {
+				return nil, fmt.Errorf("Invalid exchange rate on line %d: %v", i+1, err)
+			}
+		}
+		er, err := NewExchangeRate(record[0], record[1], rate, timestamp)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid exchange rate on line %d: %v", i+1, err)
+		}
+		rates = append(rates, er)
+	}
+	return rates, nil
+}
+
+// RateStep is one exchange rate of a conversion. If Inverted is set the rate
+// was used in the opposite direction, from Quote to Base.
+type RateStep struct {
+	ExchangeRate
+	Inverted bool
+}
+
+// From returns the currency converted from in the step
+func (s RateStep) From() string {
+	if s.Inverted {
+		return s.Quote
+	}
+	return s.Base
+}
+
+// To returns the currency converted to in the step
+func (s RateStep) To() string {
+	if s.Inverted {
+		return s.Base
+	}
+	return s.Quote
+}
+
+func (s RateStep) factor() *big.Rat {
+	r := s.Rate.native().Rat(nil)
+	if s.Inverted {
+		r.Inv(r)
+	}
+	return r
+}
+
+// Conversion is the result of Convert
+type Conversion struct {
+	// From is the converted amount
+	From Money
+	// To is the result rounded to the minor unit of its currency
+	To Money
+	// Path lists the rates used, it is empty if no conversion was necessary
+	Path []RateStep
+}
+
+// Rate returns the effective rate of the conversion rounded to the scale, digits
+func (c Conversion) Rate(digits int) Decimal {
+	return newFromRat(c.rate(), digits, ToNearestEven)
+}
+
+func (c Conversion) rate() *big.Rat {
+	rate := big.NewRat(1, 1)
+	for _, s := range c.Path {
+		rate.Mul(rate, s.factor())
+	}
+	return rate
+}
+
+// Convert converts m to the target currency with the rates of provider. It uses
+// a direct rate, the inverse of the opposite rate or, if provider is a
+// PivotRateProvider, triangulates through the pivot currency. The result is
+// computed exactly and rounded once to the minor unit of target with mode.
+func Convert(m Money, target string, provider RateProvider, mode RoundingMode) (Conversion, error) {
+	to, ok := LookupCurrency(target)
+	if !ok {
+		return Conversion{}, fmt.Errorf("Unknown currency `%s'", target)
+	}
+	path, ok := findRatePath(m.currency, to.Code, provider)
+	if !ok {
+		return Conversion{}, fmt.Errorf("No exchange rate from %s to %s", m.currency, to.Code)
+	}
+	c := Conversion{From: m, Path: path}
+	for _, s := range path {
+		if !s.Rate.native().IsFinite() || s.Rate.Cmp(Zero()) <= 0 {
+			return Conversion{}, fmt.Errorf("Invalid exchange rate %s", s.ExchangeRate)
+		}
+	}
+	if !m.amount.native().IsFinite() {
+		return Conversion{}, fmt.Errorf("Unable to convert %s", m)
+	}
+	digits := to.MinorUnits
+	if digits == NoMinorUnits {
+		digits = ConversionDigits
+	}
+	amount := new(big.Rat).Mul(m.amount.native().Rat(nil), c.rate())
+	c.To = Money{amount: newFromRat(amount, digits, mode), currency: to.Code}
+	return c, nil
+}
+
+func findRatePath(from, to string, provider RateProvider) ([]RateStep, bool) {
+	if from == to {
+		return nil, true
+	}
+	if step, ok := findRate(from, to, provider); ok {
+		return []RateStep{step}, true
+	}
+	p, ok := provider.(PivotRateProvider)
+	if !ok {
+		return nil, false
+	}
+	pivot := p.Pivot()
+	if pivot == "" || pivot == from || pivot == to {
+		return nil, false
+	}
+	first, ok := findRate(from, pivot, provider)
+	if !ok {
+		return nil, false
+	}
+	second, ok := findRate(pivot, to, provider)
+	if !ok {
+		return nil, false
+	}
+	return []RateStep{first, second}, true
+}
+
+func findRate(from, to string, provider RateProvider) (RateStep, bool) {
+	if r, ok := provider.Rate(from, to); ok {
+		return RateStep{ExchangeRate: r}, true
+	}
+	if r, ok := provider.Rate(to, from); ok {
+		return RateStep{ExchangeRate: r, Inverted: true}, true
+	}
+	return RateStep{}, false
+}
@@ -0,0 +1,141 @@
+package decimal
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func mustRate(base, quote, rate string) ExchangeRate {
+	r, err := NewExchangeRate(base, quote, MustNewFromString(rate), time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+func TestNewExchangeRate(t *testing.T) {
+	r, err := NewExchangeRate("eur", "usd", MustNewFromString("1.085"), time.Time{})
+	require.NoError(t, err)
+	require.Equal(t, "EUR/USD 1.085", r.String())
+
+	_, err = NewExchangeRate("EUR", "XYZ", NewFromInt(1), time.Time{})
+	require.EqualError(t, err, "Unknown currency `XYZ'")
+	_, err = NewExchangeRate("EUR", "USD", Zero(), time.Time{})
+	require.EqualError(t, err, "Invalid exchange rate 0 for EUR/USD")
+}
+
+func TestConvert(t *testing.T) {
+	rates := NewStaticRates("EUR",
+		mustRate("EUR", "USD", "1.085"),
+		mustRate("EUR", "GBP", "0.85"),
+		mustRate("EUR", "JPY", "160.5"),
+		mustRate("XAU", "USD", "2400"),
+	)
+	testData := []struct {
+		from   string
+		target string
+		mode   RoundingMode
+		to     string
+		path   []string
+		rate   string
+	}{
+		{from: "100 EUR", target: "USD", to: "108.50 USD", path: []string{"EUR->USD"}, rate: "1.085000"},
+		{from: "108.50 USD", target: "EUR", to: "100.00 EUR", path: []string{"USD->EUR"}, rate: "0.921659"},
+		{from: "10 USD", target: "EUR", to: "9.22 EUR", path: []string{"USD->EUR"}, rate: "0.921659"},
+		{from: "10 USD", target: "EUR", mode: ToZero, to: "9.21 EUR", path: []string{"USD->EUR"}, rate: "0.921659"},
+		{from: "100 GBP", target: "JPY", to: "18882 JPY", path: []string{"GBP->EUR", "EUR->JPY"}, rate: "188.823529"},
+		{from: "-100 GBP", target: "JPY", mode: ToNegativeInf, to: "-18883 JPY", path: []string{"GBP->EUR", "EUR->JPY"}, rate: "188.823529"},
+		{from: "1 USD", target: "XAU", to: "0.0004166667 XAU", path: []string{"USD->XAU"}, rate: "0.000417"},
+		{from: "12.345 EUR", target: "EUR", to: "12.34 EUR", rate: "1.000000"},
+	}
+	for i, j := range testData {
+		from := MustParseMoney(j.from)
+		c, err := Convert(from, j.target, rates, j.mode)
+		require.NoError(t, err, "At %d", i)
+		require.Equal(t, j.to, c.To.String(), "At %d", i)
+		require.True(t, c.From.Equals(from), "At %d", i)
+		var path []string
+		for _, s := range c.Path {
+			path = append(path, s.From()+"->"+s.To())
+		}
+		require.Equal(t, j.path, path, "At %d", i)
+		require.Equal(t, j.rate, c.Rate(6).String(), "At %d", i)
+	}
+
+	c, err := Convert(MustParseMoney("1 GBP"), "JPY", rates, ToNearestEven)
+	require.NoError(t, err)
+	require.False(t, c.Path[0].Inverted == c.Path[1].Inverted)
+	require.Equal(t, "0.85", c.Path[0].Rate.String())
+
+	_, err = Convert(MustParseMoney("1 EUR"), "CHF", rates, ToNearestEven)
+	require.EqualError(t, err, "No exchange rate from EUR to CHF")
+	_, err = Convert(MustParseMoney("1 EUR"), "XYZ", rates, ToNearestEven)
+	require.EqualError(t, err, "Unknown currency `XYZ'")
+
+	// without a pivot there is no triangulation
+	_, err = Convert(MustParseMoney("1 GBP"), "JPY", NewStaticRates("", mustRate("EUR", "GBP", "0.85"), mustRate("EUR", "JPY", "160.5")), ToNearestEven)
+	require.EqualError(t, err, "No exchange rate from GBP to JPY")
+}
+
+func TestStaticRatesKeepsLatest(t *testing.T) {
+	rates := NewStaticRates("")
+	newer := mustRate("EUR", "USD", "1.09")
+	older := newer
+	older.Rate = MustNewFromString("1.05")
+	older.Timestamp = newer.Timestamp.Add(-time.Hour)
+	rates.Set(newer)
+	rates.Set(older)
+	r, ok := rates.Rate("EUR", "USD")
+	require.True(t, ok)
+	require.Equal(t, "1.09", r.Rate.String())
+}
+
+func TestReadRatesCSV(t *testing.T) {
+	rates, err := ReadRatesCSV(strings.NewReader("base,quote,rate,timestamp\nEUR,USD,1.085,2024-05-01T12:00:00Z\nusd,chf,0.91\n"))
+	require.NoError(t, err)
+	require.Len(t, rates, 2)
+	require.Equal(t, "EUR/USD 1.085", rates[0].String())
+	require.Equal(t, time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC), rates[0].Timestamp)
+	require.Equal(t, "USD/CHF 0.91", rates[1].String())
+	require.True(t, rates[1].Timestamp.IsZero())
+
+	_, err = ReadRatesCSV(strings.NewReader("EUR,USD\n"))
+	require.EqualError(t, err, "Invalid exchange rate on line 1: expected base,quote,rate[,timestamp]")
+	_, err = ReadRatesCSV(strings.NewReader("EUR,USD,abc\n"))
+	require.EqualError(t, err, "Invalid exchange rate on line 1: Invalid decimal")
+	_, err = ReadRatesCSV(strings.NewReader("EUR,USD,1\nEUR,GBP,-1\n"))
+	require.EqualError(t, err, "Invalid exchange rate on line 2: Invalid exchange rate -1 for EUR/GBP")
+}
+
+func TestCSVRates(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rates.csv")
+	require.NoError(t, os.WriteFile(path, []byte("EUR,USD,1.085\nEUR,GBP,0.85\n"), 0600))
+
+	rates, err := LoadCSVRates(path, "EUR")
+	require.NoError(t, err)
+	c, err := Convert(MustParseMoney("85 GBP"), "USD", rates, ToNearestEven)
+	require.NoError(t, err)
+	require.Equal(t, "108.50 USD", c.To.String())
+
+	require.NoError(t, os.WriteFile(path, []byte("EUR,USD,1.1\n"), 0600))
+	require.NoError(t, rates.Reload())
+	c, err = Convert(MustParseMoney("100 EUR"), "USD", rates, ToNearestEven)
+	require.NoError(t, err)
+	require.Equal(t, "110.00 USD", c.To.String())
+	_, ok := rates.Rate("EUR", "GBP")
+	require.False(t, ok)
+
+	// a broken file keeps the previous rates
+	require.NoError(t, os.WriteFile(path, []byte("EUR,USD,x\n"), 0600))
+	require.Error(t, rates.Reload())
+	_, ok = rates.Rate("EUR", "USD")
+	require.True(t, ok)
+
+	_, err = LoadCSVRates(filepath.Join(t.TempDir(), "missing.csv"), "")
+	require.Error(t, err)
+}
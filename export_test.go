@@ -0,0 +1,4 @@
+package decimal
+
+// exported for the tests of package decimal_test
+var RegisterTestCurrency = registerTestCurrency
@@ -0,0 +1,63 @@
+package finance
+
+import (
+	"fmt"
+
+	"github.com/talon-one/decimal"
+)
+
+// Installment is one row of an amortization schedule
+type Installment struct {
+	// Period is the number of the installment, starting at 1
+	Period int
+	// Payment is the amount paid in this period, Interest plus Principal
+	Payment decimal.Decimal
+	// Interest is the part of the payment that covers the interest of the period
+	Interest decimal.Decimal
+	// Principal is the part of the payment that pays back the loan
+	Principal decimal.Decimal
+	// Balance is the amount still owed after the payment
+	Balance decimal.Decimal
+}
+
+// Amortize returns the schedule for paying back principal in nper equal payments
+// at the end of each period with the interest rate per period. Payments and
+// interest are rounded to scale digits with mode. The last payment absorbs all
+// rounding differences, so the principal parts of the rows add up to exactly
+// principal and the last balance is zero.
+func Amortize(principal, rate decimal.Decimal, nper int, scale int, mode decimal.RoundingMode) ([]Installment, error) {
+	if nper <= 0 {
+		return nil, fmt.Errorf("Number of periods must be positive, got %d", nper)
+	}
+	if principal.Cmp(decimal.Zero()) <= 0 {
+		return nil, fmt.Errorf("Principal must be positive, got %s", principal)
+	}
+	if rate.Cmp(decimal.Zero()) < 0 {
+		return nil, fmt.Errorf("Rate must not be negative, got %s", rate)
+	}
+
+	balance := decimal.QuantizeMode(principal, scale, mode)
+	if !balance.Equals(principal) {
+		return nil, fmt.Errorf("Principal %s has more than %d decimal places", principal, scale)
+	}
+	payment := PMT(rate, nper, principal, decimal.Zero(), EndOfPeriod)
+	payment = payment.Mul(decimal.NewFromInt(-1)).QuantizeMode(scale, mode)
+
+	installments := make([]Installment, nper)
+	for i := range installments {
+		interest := decimal.Mul(balance, rate).QuantizeMode(scale, mode)
+		repaid := decimal.Sub(payment, interest)
+		if i == nper-1 || repaid.Cmp(balance) > 0 {
+			repaid = decimal.NewFromDecimal(balance)
+		}
+		balance = decimal.Sub(balance, repaid)
+		installments[i] = Installment{
+			Period:    i + 1,
+			Payment:   decimal.Add(interest, repaid),
+			Interest:  interest,
+			Principal: repaid,
+			Balance:   balance,
+		}
+	}
+	return installments, nil
+}
@@ -0,0 +1,57 @@
+package finance_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/talon-one/decimal"
+	"github.com/talon-one/decimal/finance"
+)
+
+func TestAmortize(t *testing.T) {
+	principal := d("10000.00")
+	rate := decimal.Div(d("0.08"), d("12"))
+	installments, err := finance.Amortize(principal, rate, 10, 2, decimal.ToNearestAway)
+	require.NoError(t, err)
+	require.Len(t, installments, 10)
+
+	first := installments[0]
+	require.Equal(t, 1, first.Period)
+	require.Equal(t, "1037.03", first.Payment.String())
+	require.Equal(t, "66.67", first.Interest.String())
+	require.Equal(t, "970.36", first.Principal.String())
+	require.Equal(t, "9029.64", first.Balance.String())
+
+	sum := decimal.Zero()
+	for i, installment := range installments {
+		require.Equal(t, i+1, installment.Period)
+		require.True(t, decimal.Add(installment.Interest, installment.Principal).Equals(installment.Payment), "At %d", i)
+		sum = decimal.Add(sum, installment.Principal)
+	}
+	require.True(t, sum.Equals(principal), "principal parts sum up to %s", sum)
+	require.True(t, installments[9].Balance.Equals(decimal.Zero()))
+	require.Equal(t, "1037.07", installments[9].Payment.String())
+}
+
+func TestAmortizeZeroRate(t *testing.T) {
+	installments, err := finance.Amortize(d("100"), decimal.Zero(), 3, 2, decimal.ToNearestAway)
+	require.NoError(t, err)
+	require.Equal(t, "33.33", installments[0].Payment.String())
+	require.Equal(t, "33.33", installments[1].Payment.String())
+	require.Equal(t, "33.34", installments[2].Payment.String())
+	require.Equal(t, "0", installments[2].Balance.String())
+}
+
+func TestAmortizeErrors(t *testing.T) {
+	_, err := finance.Amortize(d("100"), d("0.01"), 0, 2, decimal.ToNearestAway)
+	require.EqualError(t, err, "Number of periods must be positive, got 0")
+
+	_, err = finance.Amortize(d("-100"), d("0.01"), 12, 2, decimal.ToNearestAway)
+	require.EqualError(t, err, "Principal must be positive, got -100")
+
+	_, err = finance.Amortize(d("100"), d("-0.01"), 12, 2, decimal.ToNearestAway)
+	require.EqualError(t, err, "Rate must not be negative, got -0.01")
+
+	_, err = finance.Amortize(d("100.001"), d("0.01"), 12, 2, decimal.ToNearestAway)
+	require.EqualError(t, err, "Principal 100.001 has more than 2 decimal places")
+}
@@ -0,0 +1,176 @@
+package finance
+
+import (
+	"time"
+
+	"github.com/talon-one/decimal"
+)
+
+// DayCount is a day count convention that determines the fraction of a year
+// between two dates, as used to accrue interest or pro-rate fees.
+type DayCount interface {
+	// Fraction returns the year fraction between start and end as a numerator
+	// and a denominator. It is negative if end is before start.
+	Fraction(start, end time.Time) (num, den int64)
+	// YearFraction returns the year fraction between start and end. It is exact
+	// if the fraction has a finite decimal expansion and rounded to 16 digits otherwise.
+	YearFraction(start, end time.Time) decimal.Decimal
+	// String returns the common name of the convention, such as "ACT/360"
+	String() string
+}
+
+var (
+	// Thirty360US counts every month as 30 days and the year as 360 days,
+	// with the end of month rules of the US (NASD) convention.
+	Thirty360US DayCount = thirty360US{}
+	// Thirty360E counts every month as 30 days and the year as 360 days,
+	// the 31st of a month is treated as the 30th (Eurobond basis).
+	Thirty360E DayCount = thirty360E{}
+	// Actual360 counts the actual days and the year as 360 days.
+	Actual360 DayCount = actual{basis: 360, name: "ACT/360"}
+	// Actual365Fixed counts the actual days and the year as 365 days.
+	Actual365Fixed DayCount = actual{basis: 365, name: "ACT/365F"}
+	// ActualActualISDA counts the actual days, days in leap years are divided
+	// by 366 and all other days by 365.
+	ActualActualISDA DayCount = actualActualISDA{}
+)
+
+// Accrue returns the interest principal earns between start and end at the
+// annual rate under the day count convention. It divides only once, so the
+// result is exact whenever the interest has a finite decimal expansion.
+// The result is not rounded to any scale.
+func Accrue(principal, rate decimal.Decimal, start, end time.Time, convention DayCount) decimal.Decimal {
+	num, den := convention.Fraction(start, end)
+	interest := decimal.Mul(principal, rate)
+	return interest.Mul(decimal.NewFromInt64(num)).Div(decimal.NewFromInt64(den))
+}
+
+func yearFraction(convention DayCount, start, end time.Time) decimal.Decimal {
+	num, den := convention.Fraction(start, end)
+	return decimal.Div(decimal.NewFromInt64(num), decimal.NewFromInt64(den))
+}
+
+// date strips the time of day and the location from t
+func date(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func actualDays(start, end time.Time) int64 {
+	return int64(date(end).Sub(date(start)).Hours() / 24)
+}
+
+func isLastOfFebruary(t time.Time) bool {
+	return t.Month() == time.February && t.AddDate(0, 0, 1).Month() == time.March
+}
+
+func thirty360(y1, m1, d1, y2, m2, d2 int) int64 {
+	return int64(360*(y2-y1) + 30*(m2-m1) + (d2 - d1))
+}
+
+type thirty360US struct{}
+
+func (thirty360US) Fraction(start, end time.Time) (int64, int64) {
+	if end.Before(start) {
+		num, den := thirty360US{}.Fraction(end, start)
+		return -num, den
+	}
+	y1, m1, d1 := start.Date()
+	y2, m2, d2 := end.Date()
+	if isLastOfFebruary(start) {
+		if isLastOfFebruary(end) {
+			d2 = 30
+		}
+		d1 = 30
+	}
+	if d2 == 31 && d1 >= 30 {
+		d2 = 30
+	}
+	if d1 == 31 {
+		d1 = 30
+	}
+	return thirty360(y1, int(m1), d1, y2, int(m2), d2), 360
+}
+
+func (c thirty360US) YearFraction(start, end time.Time) decimal.Decimal {
+	return yearFraction(c, start, end)
+}
+
+func (thirty360US) String() string {
+	return "30/360 US"
+}
+
+type thirty360E struct{}
+
+func (thirty360E) Fraction(start, end time.Time) (int64, int64) {
+	y1, m1, d1 := start.Date()
+	y2, m2, d2 := end.Date()
+	if d1 == 31 {
+		d1 = 30
+	}
+	if d2 == 31 {
+		d2 = 30
+	}
+	return thirty360(y1, int(m1), d1, y2, int(m2), d2), 360
+}
+
+func (c thirty360E) YearFraction(start, end time.Time) decimal.Decimal {
+	return yearFraction(c, start, end)
+}
+
+func (thirty360E) String() string {
+	return "30E/360"
+}
+
+type actual struct {
+	basis int64
+	name  string
+}
+
+func (c actual) Fraction(start, end time.Time) (int64, int64) {
+	return actualDays(start, end), c.basis
+}
+
+func (c actual) YearFraction(start, end time.Time) decimal.Decimal {
+	return yearFraction(c, start, end)
+}
+
+func (c actual) String() string {
+	return c.name
+}
+
+type actualActualISDA struct{}
+
+func (actualActualISDA) Fraction(start, end time.Time) (int64, int64) {
+	if end.Before(start) {
+		num, den := actualActualISDA{}.Fraction(end, start)
+		return -num, den
+	}
+	// days in leap years count 1/366, all others 1/365, over the common denominator 365*366
+	var num int64
+	for from := date(start); from.Before(date(end)); {
+		next := time.Date(from.Year()+1, time.January, 1, 0, 0, 0, 0, time.UTC)
+		if next.After(date(end)) {
+			next = date(end)
+		}
+		days := actualDays(from, next)
+		if isLeap(from.Year()) {
+			num += days * 365
+		} else {
+			num += days * 366
+		}
+		from = next
+	}
+	return num, 365 * 366
+}
+
+func (c actualActualISDA) YearFraction(start, end time.Time) decimal.Decimal {
+	return yearFraction(c, start, end)
+}
+
+func (actualActualISDA) String() string {
+	return "ACT/ACT ISDA"
+}
+
+func isLeap(year int) bool {
+	return year%4 == 0 && (year%100 != 0 || year%400 == 0)
+}
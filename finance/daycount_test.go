@@ -0,0 +1,94 @@
+package finance_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/talon-one/decimal"
+	"github.com/talon-one/decimal/finance"
+)
+
+func date(s string) time.Time {
+	return dates(s)[0]
+}
+
+func TestDayCountActualActualISDA(t *testing.T) {
+	// examples from the ISDA paper on the Actual/Actual conventions
+	testData := []struct {
+		start    string
+		end      string
+		expected string
+	}{
+		{start: "2003-11-01", end: "2004-05-01", expected: "0.49772438057"},
+		{start: "1999-02-01", end: "1999-07-01", expected: "0.41095890411"},
+		{start: "1999-07-01", end: "2000-07-01", expected: "1.00137734860"},
+		{start: "2002-08-15", end: "2003-07-15", expected: "0.91506849315"},
+		{start: "2003-07-15", end: "2004-01-15", expected: "0.50400479078"},
+		{start: "1999-07-30", end: "2000-01-30", expected: "0.50389250692"},
+		{start: "2000-01-30", end: "2000-06-30", expected: "0.41530054645"},
+		{start: "2004-01-01", end: "2004-01-01", expected: "0"},
+	}
+	for i, j := range testData {
+		fraction := finance.ActualActualISDA.YearFraction(date(j.start), date(j.end))
+		require.Equal(t, j.expected, rounded(fraction, 11), "At %d: %s to %s", i, j.start, j.end)
+	}
+	require.Equal(t, "ACT/ACT ISDA", finance.ActualActualISDA.String())
+}
+
+func TestDayCountThirty360(t *testing.T) {
+	testData := []struct {
+		start string
+		end   string
+		us    int64
+		eu    int64
+	}{
+		{start: "2007-01-15", end: "2007-01-30", us: 15, eu: 15},
+		{start: "2007-01-15", end: "2007-02-15", us: 30, eu: 30},
+		{start: "2007-02-28", end: "2007-03-31", us: 30, eu: 32},
+		{start: "2007-02-28", end: "2008-02-29", us: 360, eu: 361},
+		{start: "2008-02-29", end: "2009-02-28", us: 360, eu: 359},
+		{start: "2007-01-31", end: "2007-03-31", us: 60, eu: 60},
+		{start: "2007-01-15", end: "2007-03-31", us: 76, eu: 75},
+		{start: "2011-08-31", end: "2012-02-29", us: 179, eu: 179},
+	}
+	for i, j := range testData {
+		num, den := finance.Thirty360US.Fraction(date(j.start), date(j.end))
+		require.Equal(t, j.us, num, "At %d: 30/360 US from %s to %s", i, j.start, j.end)
+		require.EqualValues(t, 360, den)
+		num, den = finance.Thirty360E.Fraction(date(j.start), date(j.end))
+		require.Equal(t, j.eu, num, "At %d: 30E/360 from %s to %s", i, j.start, j.end)
+		require.EqualValues(t, 360, den)
+	}
+	require.Equal(t, "0.08333333333333333", finance.Thirty360US.YearFraction(date("2007-01-15"), date("2007-02-15")).String())
+	require.Equal(t, "-0.08333333333333333", finance.Thirty360US.YearFraction(date("2007-02-15"), date("2007-01-15")).String())
+}
+
+func TestDayCountActual(t *testing.T) {
+	start, end := date("2008-02-01"), date("2008-05-31")
+	require.Equal(t, "0.3333333333333333", finance.Actual360.YearFraction(start, end).String())
+	require.Equal(t, "0.3287671232876712", finance.Actual365Fixed.YearFraction(start, end).String())
+	require.Equal(t, "-0.3287671232876712", finance.Actual365Fixed.YearFraction(end, start).String())
+	require.Equal(t, "ACT/360", finance.Actual360.String())
+	require.Equal(t, "ACT/365F", finance.Actual365Fixed.String())
+
+	// the time of day does not matter
+	num, _ := finance.Actual360.Fraction(start.Add(23*time.Hour), end)
+	require.EqualValues(t, 120, num)
+}
+
+func TestAccrue(t *testing.T) {
+	principal, rate := d("1000000"), d("0.05")
+	start, end := date("2008-02-01"), date("2008-05-31")
+
+	require.Equal(t, "16666.66666666667", finance.Accrue(principal, rate, start, end, finance.Actual360).String())
+	require.Equal(t, "16438.35616438356", finance.Accrue(principal, rate, start, end, finance.Actual365Fixed).String())
+	require.Equal(t, "16666.66666666667", finance.Accrue(principal, rate, start, end, finance.Thirty360US).String())
+	require.Equal(t, "16527.77777777778", finance.Accrue(principal, rate, start, end, finance.Thirty360E).String())
+	require.Equal(t, "16393.44262295082", finance.Accrue(principal, rate, start, end, finance.ActualActualISDA).String())
+
+	// whole months under 30/360 accrue exactly
+	interest := finance.Accrue(d("1200.00"), rate, date("2021-01-15"), date("2021-04-15"), finance.Thirty360E)
+	require.True(t, interest.Equals(d("15")), "%s", interest)
+	require.Equal(t, "15.00", decimal.QuantizeMode(interest, 2, decimal.ToNearestAway).String())
+}
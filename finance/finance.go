@@ -0,0 +1,146 @@
+// Package finance provides time value of money calculations on decimals.
+//
+// The functions follow the argument order and sign conventions of the
+// spreadsheet functions with the same name: money paid out is negative,
+// money received is positive. Rates are per period, 5% per year paid monthly
+// is a rate of 0.05/12 over 12 periods per year.
+package finance
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/talon-one/decimal"
+)
+
+// Timing specifies whether payments are due at the beginning or the end of each period
+type Timing int
+
+const (
+	// EndOfPeriod means payments are made at the end of each period (ordinary annuity)
+	EndOfPeriod Timing = iota
+	// BeginningOfPeriod means payments are made at the beginning of each period (annuity due)
+	BeginningOfPeriod
+)
+
+func (t Timing) factor(rate decimal.Decimal) decimal.Decimal {
+	if t == BeginningOfPeriod {
+		return decimal.Add(one(), rate)
+	}
+	return one()
+}
+
+func one() decimal.Decimal {
+	return decimal.NewFromInt(1)
+}
+
+// growth returns (1 + rate) ** nper
+func growth(rate decimal.Decimal, nper int) decimal.Decimal {
+	return decimal.Pow(decimal.Add(one(), rate), decimal.NewFromInt(nper))
+}
+
+// annuity returns the factor (1 + rate * timing) * ((1 + rate) ** nper - 1) / rate
+// that turns a periodic payment into its future value.
+func annuity(rate decimal.Decimal, nper int, timing Timing) decimal.Decimal {
+	g := growth(rate, nper)
+	return decimal.Mul(timing.factor(rate), decimal.Div(decimal.Sub(g, one()), rate))
+}
+
+// FV returns the future value of an investment with periodic constant payments
+// and a constant interest rate, like the spreadsheet function FV.
+func FV(rate decimal.Decimal, nper int, pmt, pv decimal.Decimal, timing Timing) decimal.Decimal {
+	if rate.Equals(decimal.Zero()) {
+		fv := decimal.Add(pv, decimal.Mul(pmt, decimal.NewFromInt(nper)))
+		return fv.Mul(decimal.NewFromInt(-1))
+	}
+	fv := decimal.Add(decimal.Mul(pv, growth(rate, nper)), decimal.Mul(pmt, annuity(rate, nper, timing)))
+	return fv.Mul(decimal.NewFromInt(-1))
+}
+
+// PV returns the present value of an investment with periodic constant payments
+// and a constant interest rate, like the spreadsheet function PV.
+func PV(rate decimal.Decimal, nper int, pmt, fv decimal.Decimal, timing Timing) decimal.Decimal {
+	if rate.Equals(decimal.Zero()) {
+		pv := decimal.Add(fv, decimal.Mul(pmt, decimal.NewFromInt(nper)))
+		return pv.Mul(decimal.NewFromInt(-1))
+	}
+	pv := decimal.Add(fv, decimal.Mul(pmt, annuity(rate, nper, timing)))
+	return pv.Div(growth(rate, nper)).Mul(decimal.NewFromInt(-1))
+}
+
+// PMT returns the periodic payment for a loan or an investment with a constant
+// interest rate, like the spreadsheet function PMT.
+func PMT(rate decimal.Decimal, nper int, pv, fv decimal.Decimal, timing Timing) decimal.Decimal {
+	if rate.Equals(decimal.Zero()) {
+		pmt := decimal.Add(pv, fv)
+		return pmt.Div(decimal.NewFromInt(-nper))
+	}
+	pmt := decimal.Add(fv, decimal.Mul(pv, growth(rate, nper)))
+	return pmt.Div(annuity(rate, nper, timing)).Mul(decimal.NewFromInt(-1))
+}
+
+// NPER returns the number of periods needed to get from pv to fv with the
+// periodic payment pmt, like the spreadsheet function NPER. The result is not
+// rounded to a whole number of periods.
+func NPER(rate, pmt, pv, fv decimal.Decimal, timing Timing) (decimal.Decimal, error) {
+	if rate.Equals(decimal.Zero()) {
+		if pmt.Equals(decimal.Zero()) {
+			return decimal.Zero(), errors.New("Number of periods is undefined for a zero rate and zero payment")
+		}
+		nper := decimal.Add(pv, fv)
+		return nper.Div(pmt).Mul(decimal.NewFromInt(-1)), nil
+	}
+	k := decimal.Mul(pmt, timing.factor(rate))
+	num := decimal.Sub(k, decimal.Mul(fv, rate))
+	den := decimal.Add(k, decimal.Mul(pv, rate))
+	if den.Equals(decimal.Zero()) || decimal.Div(num, den).Cmp(decimal.Zero()) <= 0 {
+		return decimal.Zero(), fmt.Errorf("No number of periods gets from %s to %s with payment %s", pv, fv, pmt)
+	}
+	nper := decimal.Log(decimal.Div(num, den))
+	return nper.Div(decimal.Log(decimal.Add(one(), rate))), nil
+}
+
+// RATE returns the interest rate per period that gets from pv to fv within nper
+// periods with the periodic payment pmt, like the spreadsheet function RATE.
+// guess is the starting point of the search, 0.1 is a common choice.
+// It returns an error wrapping ErrNoConvergence if no rate can be found.
+func RATE(nper int, pmt, pv, fv decimal.Decimal, timing Timing, guess decimal.Decimal) (decimal.Decimal, error) {
+	if nper <= 0 {
+		return decimal.Zero(), fmt.Errorf("Number of periods must be positive, got %d", nper)
+	}
+	n := decimal.NewFromInt(nper)
+	t := decimal.NewFromInt(int(timing))
+	f := func(r decimal.Decimal) decimal.Decimal {
+		if r.Equals(decimal.Zero()) {
+			return decimal.Add(decimal.Add(pv, fv), decimal.Mul(pmt, n))
+		}
+		y := decimal.Add(decimal.Mul(pv, growth(r, nper)), decimal.Mul(pmt, annuity(r, nper, timing)))
+		return y.Add(fv)
+	}
+	df := func(r decimal.Decimal) decimal.Decimal {
+		if r.Equals(decimal.Zero()) {
+			// limit of the derivative at zero
+			y := decimal.Mul(pv, n)
+			return y.Add(decimal.Mul(pmt, decimal.Mul(n, decimal.Add(decimal.Sub(n, one()).Div(decimal.NewFromInt(2)), t))))
+		}
+		g := growth(r, nper)
+		dg := decimal.Mul(n, growth(r, nper-1))
+		gm1 := decimal.Sub(g, one())
+		// d/dr (1 + r*t) * (g - 1) / r
+		da := decimal.Div(decimal.Mul(t, gm1), r)
+		da.Add(decimal.Mul(decimal.Add(one(), decimal.Mul(r, t)), decimal.Div(decimal.Sub(decimal.Mul(dg, r), gm1), decimal.Mul(r, r))))
+		y := decimal.Mul(pv, dg)
+		return y.Add(decimal.Mul(pmt, da))
+	}
+	return solve(f, df, guess)
+}
+
+// NPV returns the net present value of periodic cash flows at the discount rate,
+// like the spreadsheet function NPV. The first value is discounted by one period.
+func NPV(rate decimal.Decimal, values []decimal.Decimal) decimal.Decimal {
+	npv := decimal.Zero()
+	for i, v := range values {
+		npv.Add(decimal.Div(v, growth(rate, i+1)))
+	}
+	return npv
+}
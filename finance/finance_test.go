@@ -0,0 +1,78 @@
+package finance_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/talon-one/decimal"
+	"github.com/talon-one/decimal/finance"
+)
+
+func d(s string) decimal.Decimal {
+	return decimal.MustNewFromString(s)
+}
+
+func rounded(x decimal.Decimal, places int) string {
+	return decimal.QuantizeMode(x, places, decimal.ToNearestAway).String()
+}
+
+func TestPMT(t *testing.T) {
+	rate := decimal.Div(d("0.08"), d("12"))
+	require.Equal(t, "-1037.03", rounded(finance.PMT(rate, 10, d("10000"), decimal.Zero(), finance.EndOfPeriod), 2))
+	require.Equal(t, "-1030.16", rounded(finance.PMT(rate, 10, d("10000"), decimal.Zero(), finance.BeginningOfPeriod), 2))
+
+	rate = decimal.Div(d("0.06"), d("12"))
+	require.Equal(t, "-129.08", rounded(finance.PMT(rate, 18*12, decimal.Zero(), d("50000"), finance.EndOfPeriod), 2))
+
+	require.Equal(t, "-100.00", rounded(finance.PMT(decimal.Zero(), 12, d("1200"), decimal.Zero(), finance.EndOfPeriod), 2))
+}
+
+func TestFV(t *testing.T) {
+	require.Equal(t, "2581.40", rounded(finance.FV(d("0.005"), 10, d("-200"), d("-500"), finance.BeginningOfPeriod), 2))
+	require.Equal(t, "12682.50", rounded(finance.FV(decimal.Div(d("0.12"), d("12")), 12, d("-1000"), decimal.Zero(), finance.EndOfPeriod), 2))
+	require.Equal(t, "2900.00", rounded(finance.FV(decimal.Zero(), 12, d("-200"), d("-500"), finance.EndOfPeriod), 2))
+}
+
+func TestPV(t *testing.T) {
+	require.Equal(t, "-59777.15", rounded(finance.PV(decimal.Div(d("0.08"), d("12")), 12*20, d("500"), decimal.Zero(), finance.EndOfPeriod), 2))
+	require.Equal(t, "-6000.00", rounded(finance.PV(decimal.Zero(), 12, d("500"), decimal.Zero(), finance.EndOfPeriod), 2))
+}
+
+func TestNPER(t *testing.T) {
+	n, err := finance.NPER(decimal.Div(d("0.12"), d("12")), d("-100"), d("-1000"), d("10000"), finance.BeginningOfPeriod)
+	require.NoError(t, err)
+	require.Equal(t, "59.6739", rounded(n, 4))
+
+	n, err = finance.NPER(decimal.Zero(), d("-100"), d("1000"), decimal.Zero(), finance.EndOfPeriod)
+	require.NoError(t, err)
+	require.Equal(t, "10", n.String())
+
+	_, err = finance.NPER(decimal.Zero(), decimal.Zero(), d("1000"), decimal.Zero(), finance.EndOfPeriod)
+	require.EqualError(t, err, "Number of periods is undefined for a zero rate and zero payment")
+
+	_, err = finance.NPER(d("0.01"), d("-5"), d("1000"), decimal.Zero(), finance.EndOfPeriod)
+	require.EqualError(t, err, "No number of periods gets from 1000 to 0 with payment -5")
+}
+
+func TestRATE(t *testing.T) {
+	r, err := finance.RATE(48, d("-200"), d("8000"), decimal.Zero(), finance.EndOfPeriod, d("0.1"))
+	require.NoError(t, err)
+	require.Equal(t, "0.0077014725", rounded(r, 10))
+
+	r, err = finance.RATE(10, d("-1030.16"), d("10000"), decimal.Zero(), finance.BeginningOfPeriod, d("0.1"))
+	require.NoError(t, err)
+	require.Equal(t, "0.006666", rounded(r, 6))
+
+	r, err = finance.RATE(12, d("-100"), d("1200"), decimal.Zero(), finance.EndOfPeriod, decimal.Zero())
+	require.NoError(t, err)
+	require.Equal(t, "0", rounded(r, 10))
+
+	_, err = finance.RATE(0, d("-100"), d("1200"), decimal.Zero(), finance.EndOfPeriod, d("0.1"))
+	require.EqualError(t, err, "Number of periods must be positive, got 0")
+}
+
+func TestNPV(t *testing.T) {
+	values := []decimal.Decimal{d("-10000"), d("3000"), d("4200"), d("6800")}
+	require.Equal(t, "1188.44", rounded(finance.NPV(d("0.1"), values), 2))
+	require.Equal(t, "4000", finance.NPV(decimal.Zero(), values).String())
+}
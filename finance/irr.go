@@ -0,0 +1,190 @@
+package finance
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/talon-one/decimal"
+)
+
+// ErrNoConvergence is returned when an iterative solver like IRR fails to find a root
+var ErrNoConvergence = errors.New("No convergence")
+
+const maxIterations = 100
+
+var (
+	// tolerance is the precision a rate is solved to
+	tolerance = decimal.New(1, 12)
+	// lowestRate keeps 1 + rate positive while searching for a root
+	lowestRate = decimal.MustNewFromString("-0.999999999")
+	// highestRate limits the search for an upper bracket in the bisection
+	highestRate = decimal.NewFromInt(1000000)
+)
+
+// solve finds a rate r with f(r) = 0. It starts with Newton's method at guess
+// and falls back to bisection if that diverges or leaves the valid range.
+func solve(f, df func(decimal.Decimal) decimal.Decimal, guess decimal.Decimal) (decimal.Decimal, error) {
+	if r, ok := newton(f, df, guess); ok {
+		return r, nil
+	}
+	return bisect(f)
+}
+
+func newton(f, df func(decimal.Decimal) decimal.Decimal, guess decimal.Decimal) (decimal.Decimal, bool) {
+	r := decimal.NewFromDecimal(guess)
+	for i := 0; i < maxIterations; i++ {
+		if r.Cmp(lowestRate) < 0 || r.IsNaN() {
+			return decimal.Zero(), false
+		}
+		d := df(r)
+		if d.Equals(decimal.Zero()) || d.IsNaN() {
+			return decimal.Zero(), false
+		}
+		step := decimal.Div(f(r), d)
+		r = decimal.Sub(r, step)
+		if decimal.Abs(step).Cmp(tolerance) < 0 && r.Cmp(lowestRate) >= 0 {
+			return r, true
+		}
+	}
+	return decimal.Zero(), false
+}
+
+func bisect(f func(decimal.Decimal) decimal.Decimal) (decimal.Decimal, error) {
+	lo, hi := decimal.NewFromDecimal(lowestRate), decimal.NewFromInt(1)
+	flo := f(lo)
+	for sign(f(hi)) == sign(flo) {
+		if hi.Cmp(highestRate) >= 0 {
+			return decimal.Zero(), fmt.Errorf("%w: no rate between %s and %s changes the sign", ErrNoConvergence, lowestRate, highestRate)
+		}
+		hi = decimal.Mul(hi, decimal.NewFromInt(10))
+	}
+	two := decimal.NewFromInt(2)
+	for i := 0; i < 4*maxIterations; i++ {
+		mid := decimal.Add(lo, hi).Div(two)
+		fmid := f(mid)
+		if sign(fmid) == 0 || decimal.Sub(hi, lo).Cmp(tolerance) < 0 {
+			return mid, nil
+		}
+		if sign(fmid) == sign(flo) {
+			lo, flo = mid, fmid
+		} else {
+			hi = mid
+		}
+	}
+	return decimal.Zero(), fmt.Errorf("%w after %d bisection steps", ErrNoConvergence, 4*maxIterations)
+}
+
+func checkSigns(values []decimal.Decimal) error {
+	var pos, neg bool
+	for _, v := range values {
+		switch sign(v) {
+		case 1:
+			pos = true
+		case -1:
+			neg = true
+		}
+	}
+	if !pos || !neg {
+		return errors.New("Cash flows need at least one positive and one negative value")
+	}
+	return nil
+}
+
+// IRR returns the internal rate of return of periodic cash flows, like the
+// spreadsheet function IRR. guess is the starting point of the search, 0.1 is
+// a common choice. It returns an error wrapping ErrNoConvergence if no rate can be found.
+func IRR(values []decimal.Decimal, guess decimal.Decimal) (decimal.Decimal, error) {
+	if err := checkSigns(values); err != nil {
+		return decimal.Zero(), err
+	}
+	f := func(r decimal.Decimal) decimal.Decimal {
+		npv := decimal.Zero()
+		for i, v := range values {
+			npv.Add(decimal.Div(v, growth(r, i)))
+		}
+		return npv
+	}
+	df := func(r decimal.Decimal) decimal.Decimal {
+		d := decimal.Zero()
+		for i, v := range values {
+			d.Sub(decimal.Div(decimal.Mul(v, decimal.NewFromInt(i)), growth(r, i+1)))
+		}
+		return d
+	}
+	return solve(f, df, guess)
+}
+
+// yearFractions returns the number of 365 day years between the first date
+// and each date.
+func yearFractions(values []decimal.Decimal, dates []time.Time) ([]decimal.Decimal, error) {
+	if len(values) != len(dates) {
+		return nil, fmt.Errorf("Got %d values but %d dates", len(values), len(dates))
+	}
+	if len(values) == 0 {
+		return nil, errors.New("Cash flows are empty")
+	}
+	days := decimal.NewFromInt(365)
+	fractions := make([]decimal.Decimal, len(dates))
+	for i, d := range dates {
+		n := actualDays(dates[0], d)
+		if n < 0 {
+			return nil, fmt.Errorf("Date %s is before the first date %s", d.Format("2006-01-02"), dates[0].Format("2006-01-02"))
+		}
+		fractions[i] = decimal.NewFromInt64(n).Div(days)
+	}
+	return fractions, nil
+}
+
+func xnpv(rate decimal.Decimal, values, fractions []decimal.Decimal) decimal.Decimal {
+	base := decimal.Add(one(), rate)
+	npv := decimal.Zero()
+	for i, v := range values {
+		npv.Add(decimal.Div(v, decimal.Pow(base, fractions[i])))
+	}
+	return npv
+}
+
+// XNPV returns the net present value of cash flows on arbitrary dates, like the
+// spreadsheet function XNPV. Each value is discounted by the number of 365 day
+// years since the first date.
+func XNPV(rate decimal.Decimal, values []decimal.Decimal, dates []time.Time) (decimal.Decimal, error) {
+	fractions, err := yearFractions(values, dates)
+	if err != nil {
+		return decimal.Zero(), err
+	}
+	if rate.Cmp(decimal.NewFromInt(-1)) <= 0 {
+		return decimal.Zero(), fmt.Errorf("Rate %s must be greater than -1", rate)
+	}
+	return xnpv(rate, values, fractions), nil
+}
+
+// XIRR returns the internal rate of return of cash flows on arbitrary dates, like
+// the spreadsheet function XIRR. It returns an error wrapping ErrNoConvergence if
+// no rate can be found.
+func XIRR(values []decimal.Decimal, dates []time.Time, guess decimal.Decimal) (decimal.Decimal, error) {
+	fractions, err := yearFractions(values, dates)
+	if err != nil {
+		return decimal.Zero(), err
+	}
+	if err := checkSigns(values); err != nil {
+		return decimal.Zero(), err
+	}
+	f := func(r decimal.Decimal) decimal.Decimal {
+		return xnpv(r, values, fractions)
+	}
+	df := func(r decimal.Decimal) decimal.Decimal {
+		base := decimal.Add(one(), r)
+		d := decimal.Zero()
+		for i, v := range values {
+			e := decimal.Add(fractions[i], one())
+			d.Sub(decimal.Div(decimal.Mul(v, fractions[i]), decimal.Pow(base, e)))
+		}
+		return d
+	}
+	return solve(f, df, guess)
+}
+
+func sign(d decimal.Decimal) int {
+	return d.Cmp(decimal.Zero())
+}
@@ -0,0 +1,91 @@
+package finance_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/talon-one/decimal"
+	"github.com/talon-one/decimal/finance"
+)
+
+func TestIRR(t *testing.T) {
+	testData := []struct {
+		values   []string
+		expected string
+	}{
+		{values: []string{"-70000", "12000", "15000", "18000", "21000", "26000"}, expected: "0.0866309480"},
+		{values: []string{"-70000", "12000", "15000", "18000", "21000"}, expected: "-0.0212448483"},
+		{values: []string{"-100", "110"}, expected: "0.1000000000"},
+		{values: []string{"-100", "0", "0", "0", "0", "0", "0", "0", "0", "0", "10000"}, expected: "0.5848931925"},
+	}
+	for i, j := range testData {
+		values := make([]decimal.Decimal, len(j.values))
+		for k, v := range j.values {
+			values[k] = d(v)
+		}
+		r, err := finance.IRR(values, d("0.1"))
+		require.NoError(t, err, "At %d", i)
+		require.Equal(t, j.expected, rounded(r, 10), "At %d", i)
+		require.True(t, finance.NPV(r, values[1:]).Add(values[0]).AlmostEquals(decimal.Zero(), d("0.000001")), "At %d", i)
+	}
+
+	_, err := finance.IRR([]decimal.Decimal{d("100"), d("110")}, d("0.1"))
+	require.EqualError(t, err, "Cash flows need at least one positive and one negative value")
+
+	// a bad guess makes Newton's method leave the valid range, bisection still finds the rate
+	r, err := finance.IRR([]decimal.Decimal{d("-100"), d("110")}, d("-0.9999"))
+	require.NoError(t, err)
+	require.Equal(t, "0.1000000000", rounded(r, 10))
+
+	// no rate discounts these flows to zero
+	_, err = finance.IRR([]decimal.Decimal{d("-100"), d("150"), d("-100")}, d("0.1"))
+	require.True(t, errors.Is(err, finance.ErrNoConvergence), "%v", err)
+}
+
+func dates(values ...string) []time.Time {
+	result := make([]time.Time, len(values))
+	for i, v := range values {
+		t, err := time.Parse("2006-01-02", v)
+		if err != nil {
+			panic(err)
+		}
+		result[i] = t
+	}
+	return result
+}
+
+func TestXNPV(t *testing.T) {
+	values := []decimal.Decimal{d("-10000"), d("2750"), d("4250"), d("3250"), d("2750")}
+	when := dates("2008-01-01", "2008-03-01", "2008-10-30", "2009-02-15", "2009-04-01")
+
+	npv, err := finance.XNPV(d("0.09"), values, when)
+	require.NoError(t, err)
+	require.Equal(t, "2086.6476", rounded(npv, 4))
+
+	_, err = finance.XNPV(d("0.09"), values, when[:4])
+	require.EqualError(t, err, "Got 5 values but 4 dates")
+
+	_, err = finance.XNPV(d("0.09"), values[:2], dates("2008-01-01", "2007-12-31"))
+	require.EqualError(t, err, "Date 2007-12-31 is before the first date 2008-01-01")
+
+	_, err = finance.XNPV(d("-1"), values, when)
+	require.EqualError(t, err, "Rate -1 must be greater than -1")
+	_, err = finance.XNPV(d("-1.0000000001"), values, when)
+	require.EqualError(t, err, "Rate -1.0000000001 must be greater than -1")
+	_, err = finance.XNPV(d("-0.9999999995"), values, when)
+	require.NoError(t, err)
+}
+
+func TestXIRR(t *testing.T) {
+	values := []decimal.Decimal{d("-10000"), d("2750"), d("4250"), d("3250"), d("2750")}
+	when := dates("2008-01-01", "2008-03-01", "2008-10-30", "2009-02-15", "2009-04-01")
+
+	r, err := finance.XIRR(values, when, d("0.1"))
+	require.NoError(t, err)
+	require.Equal(t, "0.373362534", rounded(r, 9))
+
+	_, err = finance.XIRR(nil, nil, d("0.1"))
+	require.EqualError(t, err, "Cash flows are empty")
+}
@@ -0,0 +1,260 @@
+package decimal
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// SignStyle determines how the sign of a formatted number is shown
+type SignStyle int
+
+const (
+	// SignAuto shows the minus sign of negative numbers
+	SignAuto SignStyle = iota
+	// SignAlways shows a plus sign for positive numbers and zero as well
+	SignAlways
+	// SignNever omits the sign
+	SignNever
+	// SignAccounting encloses negative numbers in parentheses, such as (12.00)
+	SignAccounting
+)
+
+// SymbolStyle determines how the currency of formatted money is shown
+type SymbolStyle int
+
+const (
+	// SymbolLocal shows the symbol used in the locale, such as € or US$
+	SymbolLocal SymbolStyle = iota
+	// SymbolCode shows the currency code, such as EUR
+	SymbolCode
+	// SymbolNone omits the currency
+	SymbolNone
+)
+
+// SymbolPosition determines where the currency of formatted money is shown
+type SymbolPosition int
+
+const (
+	// PositionLocale places the currency as the locale does
+	PositionLocale SymbolPosition = iota
+	// PositionBefore places the currency before the number
+	PositionBefore
+	// PositionAfter places the currency after the number
+	PositionAfter
+)
+
+// Formatter formats decimals and money for a locale. The zero value of the
+// options formats with grouping, the scale of the value, or the minor unit of
+// the currency for money, and a minus sign for negative numbers. Formatter is
+// immutable, the With methods return a modified copy.
+type Formatter struct {
+	locale      Locale
+	noGrouping  bool
+	fractionSet bool
+	minFraction int
+	maxFraction int
+	mode        RoundingMode
+	sign        SignStyle
+	symbol      SymbolStyle
+	position    SymbolPosition
+}
+
+// NewFormatter returns a formatter for the locale with the tag, see LookupLocale
+func NewFormatter(tag string) (Formatter, error) {
+	l, ok := LookupLocale(tag)
+	if !ok {
+		return Formatter{}, fmt.Errorf("Unknown locale `%s'", tag)
+	}
+	return l.Formatter(), nil
+}
+
+// MustNewFormatter is like NewFormatter but panics if the locale is unknown
+func MustNewFormatter(tag string) Formatter {
+	f, err := NewFormatter(tag)
+	if err != nil {
+		panic(err)
+	}
+	return f
+}
+
+// Formatter returns a formatter for l
+func (l Locale) Formatter() Formatter {
+	return Formatter{locale: l}
+}
+
+// Locale returns the locale of f
+func (f Formatter) Locale() Locale {
+	return f.locale
+}
+
+// WithGrouping returns f with digit grouping turned on or off
+func (f Formatter) WithGrouping(grouping bool) Formatter {
+	f.noGrouping = !grouping
+	return f
+}
+
+// WithFractionDigits returns f showing at least min and at most max fractional
+// digits. Values with more digits are rounded with the rounding mode of f.
+func (f Formatter) WithFractionDigits(min, max int) Formatter {
+	if max < min {
+		max = min
+	}
+	f.fractionSet, f.minFraction, f.maxFraction = true, min, max
+	return f
+}
+
+// WithRoundingMode returns f rounding with mode, ToNearestEven by default
+func (f Formatter) WithRoundingMode(mode RoundingMode) Formatter {
+	f.mode = mode
+	return f
+}
+
+// WithSign returns f showing signs with style
+func (f Formatter) WithSign(style SignStyle) Formatter {
+	f.sign = style
+	return f
+}
+
+// WithSymbol returns f showing currencies with style
+func (f Formatter) WithSymbol(style SymbolStyle) Formatter {
+	f.symbol = style
+	return f
+}
+
+// WithSymbolPosition returns f placing currencies at position
+func (f Formatter) WithSymbolPosition(position SymbolPosition) Formatter {
+	f.position = position
+	return f
+}
+
+// Format returns d formatted for the locale of f, such as "1.234,5" for de-DE
+func (f Formatter) Format(d Decimal) string {
+	min, max := 0, -1
+	if f.fractionSet {
+		min, max = f.minFraction, f.maxFraction
+	}
+	return f.format(d, "", min, max)
+}
+
+// FormatMoney returns m formatted for the locale of f, such as "1.234,50 €" for de-DE
+func (f Formatter) FormatMoney(m Money) string {
+	min, max := 0, -1
+	if f.fractionSet {
+		min, max = f.minFraction, f.maxFraction
+	} else if units := m.MinorUnits(); units != NoMinorUnits {
+		min, max = units, units
+	}
+	return f.format(m.amount, m.currency, min, max)
+}
+
+func (f Formatter) format(d Decimal, currency string, min, max int) string {
+	x := NewFromDecimal(d)
+	var number string
+	if x.native().IsFinite() {
+		if max >= 0 && x.Scale() > max {
+			x.QuantizeMode(max, f.mode)
+		}
+		if x.Scale() < min {
+			x.QuantizeMode(min, f.mode)
+		}
+		integer, fraction := plainDigits(x)
+		number = f.group(integer)
+		if fraction != "" {
+			number += f.locale.Decimal + fraction
+		}
+	} else if x.IsNaN() {
+		return "NaN"
+	} else {
+		number = "∞"
+	}
+
+	body := number
+	if currency != "" && f.symbol != SymbolNone {
+		body = f.placeSymbol(number, currency)
+	}
+
+	negative := x.native().Signbit() && !(x.native().IsFinite() && x.native().Sign() == 0)
+	switch {
+	case f.sign == SignNever:
+		return body
+	case negative && f.sign == SignAccounting:
+		return "(" + body + ")"
+	case negative:
+		return f.locale.Minus + body
+	case f.sign == SignAlways:
+		return "+" + body
+	}
+	return body
+}
+
+// placeSymbol adds the currency symbol to number following the currency
+// pattern of the locale. Like CLDR it separates symbols ending in letters
+// from the number by a space.
+func (f Formatter) placeSymbol(number, currency string) string {
+	symbol := currency
+	if f.symbol == SymbolLocal {
+		symbol = f.locale.Symbol(currency)
+	}
+	pattern := f.locale.CurrencyPattern
+	before := strings.HasPrefix(pattern, "¤")
+	switch f.position {
+	case PositionBefore:
+		before = true
+	case PositionAfter:
+		before = false
+	}
+	space := ""
+	if strings.ContainsAny(pattern, "\u00a0\u202f ") {
+		space = "\u00a0"
+	}
+	if before {
+		if r, _ := utf8.DecodeLastRuneInString(symbol); unicode.IsLetter(r) {
+			space = "\u00a0"
+		}
+		return symbol + space + number
+	}
+	if r, _ := utf8.DecodeRuneInString(symbol); unicode.IsLetter(r) {
+		space = "\u00a0"
+	}
+	return number + space + symbol
+}
+
+// group inserts the group separators of the locale into the integer digits
+func (f Formatter) group(integer string) string {
+	primary, secondary := f.locale.Grouping[0], f.locale.Grouping[1]
+	if f.noGrouping || primary <= 0 || len(integer)-primary < f.locale.MinimumGrouping || len(integer) <= primary {
+		return integer
+	}
+	if secondary <= 0 {
+		secondary = primary
+	}
+	groups := []string{integer[len(integer)-primary:]}
+	rest := integer[:len(integer)-primary]
+	for len(rest) > secondary {
+		groups = append([]string{rest[len(rest)-secondary:]}, groups...)
+		rest = rest[:len(rest)-secondary]
+	}
+	groups = append([]string{rest}, groups...)
+	return strings.Join(groups, f.locale.Group)
+}
+
+// plainDigits returns the integer and fractional digits of the absolute
+// value of the finite d, the fractional digits have the scale of d
+func plainDigits(d Decimal) (integer, fraction string) {
+	r := d.native().Rat(nil)
+	r.Abs(r)
+	scale := d.Scale()
+	if scale <= 0 {
+		return r.Num().String(), ""
+	}
+	n := new(big.Int).Mul(r.Num(), new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(scale)), nil))
+	n.Quo(n, r.Denom())
+	digits := n.String()
+	if len(digits) <= scale {
+		digits = strings.Repeat("0", scale-len(digits)+1) + digits
+	}
+	return digits[:len(digits)-scale], digits[len(digits)-scale:]
+}
@@ -0,0 +1,138 @@
+package decimal_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/talon-one/decimal"
+)
+
+func TestLookupLocale(t *testing.T) {
+	l, ok := decimal.LookupLocale("de_ch")
+	require.True(t, ok)
+	require.Equal(t, "de-CH", l.Tag)
+	require.Equal(t, "’", l.Group)
+
+	l, ok = decimal.LookupLocale("de-LU")
+	require.True(t, ok)
+	require.Equal(t, "de-DE", l.Tag)
+
+	l, ok = decimal.LookupLocale("en")
+	require.True(t, ok)
+	require.Equal(t, "en-US", l.Tag)
+
+	_, ok = decimal.LookupLocale("xx-YY")
+	require.False(t, ok)
+
+	l, ok = decimal.LookupLocale("en-AU")
+	require.True(t, ok)
+	l.Symbols["AUD"] = "A$"
+	l, _ = decimal.LookupLocale("en-AU")
+	require.Equal(t, "$", l.Symbol("AUD"))
+
+	require.Contains(t, decimal.Locales(), "en-IN")
+
+	_, err := decimal.NewFormatter("xx")
+	require.EqualError(t, err, "Unknown locale `xx'")
+}
+
+func TestFormatMoney(t *testing.T) {
+	testData := []struct {
+		locale   string
+		money    string
+		expected string
+	}{
+		{locale: "de-DE", money: "1234.5 EUR", expected: "1.234,50\u00a0€"},
+		{locale: "en-US", money: "1234.5 EUR", expected: "€1,234.50"},
+		{locale: "en-US", money: "-1234.5 USD", expected: "-$1,234.50"},
+		{locale: "de-CH", money: "1234.5 CHF", expected: "CHF\u00a01’234.50"},
+		{locale: "en-US", money: "1234.5 CHF", expected: "CHF\u00a01,234.50"},
+		{locale: "en-IN", money: "1234567 INR", expected: "₹12,34,567.00"},
+		{locale: "fr-FR", money: "1234567.891 EUR", expected: "1\u202f234\u202f567,89\u00a0€"},
+		{locale: "fr-FR", money: "10 USD", expected: "10,00\u00a0$US"},
+		{locale: "en-CA", money: "10 CAD", expected: "$10.00"},
+		{locale: "en-GB", money: "10 USD", expected: "US$10.00"},
+		{locale: "sv-SE", money: "-5 SEK", expected: "\u22125,00\u00a0kr"},
+		{locale: "ja-JP", money: "1234 JPY", expected: "￥1,234"},
+		{locale: "es-ES", money: "1234 EUR", expected: "1234,00\u00a0€"},
+		{locale: "es-ES", money: "12345 EUR", expected: "12.345,00\u00a0€"},
+		{locale: "nl-NL", money: "0 EUR", expected: "€\u00a00,00"},
+		{locale: "en-US", money: "1.23456 XAU", expected: "XAU\u00a01.23456"},
+	}
+	for i, j := range testData {
+		f := decimal.MustNewFormatter(j.locale)
+		require.Equal(t, j.expected, f.FormatMoney(decimal.MustParseMoney(j.money)), "At %d", i)
+	}
+}
+
+func TestFormatOptions(t *testing.T) {
+	us := decimal.MustNewFormatter("en-US")
+	de := decimal.MustNewFormatter("de-DE")
+	m := decimal.MustParseMoney("-1234.5 EUR")
+
+	require.Equal(t, "(€1,234.50)", us.WithSign(decimal.SignAccounting).FormatMoney(m))
+	require.Equal(t, "(1.234,50\u00a0€)", de.WithSign(decimal.SignAccounting).FormatMoney(m))
+	require.Equal(t, "€1,234.50", us.WithSign(decimal.SignNever).FormatMoney(m))
+	require.Equal(t, "+€1,234.50", us.WithSign(decimal.SignAlways).FormatMoney(m.Neg()))
+	require.Equal(t, "-EUR\u00a01,234.50", us.WithSymbol(decimal.SymbolCode).FormatMoney(m))
+	require.Equal(t, "-1,234.50", us.WithSymbol(decimal.SymbolNone).FormatMoney(m))
+	require.Equal(t, "-1,234.50€", us.WithSymbolPosition(decimal.PositionAfter).FormatMoney(m))
+	require.Equal(t, "-€\u00a01.234,50", de.WithSymbolPosition(decimal.PositionBefore).FormatMoney(m))
+	require.Equal(t, "-€1234.50", us.WithGrouping(false).FormatMoney(m))
+	require.Equal(t, "-€1,234.5", us.WithFractionDigits(0, 1).FormatMoney(m))
+	require.Equal(t, "-€1,234", us.WithFractionDigits(0, 0).FormatMoney(m))
+	require.Equal(t, "-€1,235", us.WithFractionDigits(0, 0).WithRoundingMode(decimal.ToNearestAway).FormatMoney(m))
+	require.Equal(t, "-1.234,5", de.WithSymbol(decimal.SymbolNone).Format(m.Amount()))
+}
+
+func TestFormat(t *testing.T) {
+	testData := []struct {
+		locale   string
+		value    string
+		min      int
+		max      int
+		sign     decimal.SignStyle
+		expected string
+	}{
+		{locale: "en-US", value: "1234.5", min: -1, expected: "1,234.5"},
+		{locale: "de-DE", value: "1234.5", min: -1, expected: "1.234,5"},
+		{locale: "en-IN", value: "1234567", min: 2, max: 2, expected: "12,34,567.00"},
+		{locale: "en-US", value: "-12", min: 2, max: 2, sign: decimal.SignAccounting, expected: "(12.00)"},
+		{locale: "en-US", value: "12", min: 2, max: 2, sign: decimal.SignAccounting, expected: "12.00"},
+		{locale: "en-US", value: "1.005", min: 0, max: 2, expected: "1.00"},
+		{locale: "en-US", value: "1.5", min: 2, max: 4, expected: "1.50"},
+		{locale: "en-US", value: "1.23456", min: 2, max: 4, expected: "1.2346"},
+		{locale: "en-US", value: "0", min: -1, expected: "0"},
+		{locale: "en-US", value: "-0.001", min: 2, max: 2, expected: "0.00"},
+		{locale: "en-US", value: "0.000001", min: -1, expected: "0.000001"},
+		{locale: "en-US", value: "1E+6", min: -1, expected: "1,000,000"},
+		{locale: "en-US", value: "5", min: -1, sign: decimal.SignAlways, expected: "+5"},
+		{locale: "fr-CH", value: "-1234567.25", min: -1, expected: "-1\u202f234\u202f567,25"},
+		{locale: "nb-NO", value: "-1", min: -1, expected: "\u22121"},
+		{locale: "en-US", value: "-Inf", min: -1, expected: "-∞"},
+	}
+	for i, j := range testData {
+		data := setup(j.value)
+		f := decimal.MustNewFormatter(j.locale).WithSign(j.sign)
+		if j.min >= 0 {
+			f = f.WithFractionDigits(j.min, j.max)
+		}
+		require.Equal(t, j.expected, f.Format(data.Decimals[0]), "At %d", i)
+		data.VerifyIntegrity(t)
+	}
+	nan := decimal.DivMode(decimal.NewFromInt(1), decimal.Zero(), 2, decimal.ToNearestEven)
+	require.Equal(t, "NaN", decimal.MustNewFormatter("en-US").Format(nan))
+}
+
+func TestCustomLocale(t *testing.T) {
+	l, ok := decimal.LookupLocale("en-US")
+	require.True(t, ok)
+	l.Group = "'"
+	l.Symbols = map[string]string{"STARS": "stars"}
+	require.NoError(t, decimal.RegisterTestCurrency(t, decimal.Currency{Code: "STARS", MinorUnits: 0}))
+	f := l.Formatter().WithSymbolPosition(decimal.PositionAfter)
+	require.Equal(t, "1'234.5", f.Format(decimal.MustNewFromString("1234.5")))
+	require.Equal(t, "1'234\u00a0stars", f.FormatMoney(decimal.MustParseMoney("1234.5 STARS")))
+	require.Equal(t, "€", l.Symbol("EUR"))
+	require.Equal(t, "XYZ", l.Symbol("XYZ"))
+}
@@ -0,0 +1,207 @@
+package decimal
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// ParseFraction parses a fraction such as "3/8", a mixed number such as
+// "1 1/2" or "-2 3/4", or an integer, and rounds it to the scale, digits, with
+// mode. All parts are unsigned integers, a sign may only lead the input.
+func ParseFraction(s string, digits int, mode RoundingMode) (Decimal, error) {
+	r, err := parseFraction(s)
+	if err != nil {
+		return Decimal{}, err
+	}
+	return newFromRat(r, digits, mode), nil
+}
+
+func parseFraction(s string) (*big.Rat, error) {
+	invalid := func(reason string) error {
+		if reason == "" {
+			return fmt.Errorf("Invalid fraction `%s'", s)
+		}
+		return fmt.Errorf("Invalid fraction `%s': %s", s, reason)
+	}
+	t := strings.TrimSpace(s)
+	negative := false
+	if strings.HasPrefix(t, "-") || strings.HasPrefix(t, "+") {
+		negative = t[0] == '-'
+		t = t[1:]
+	}
+	fields := strings.Fields(t)
+	if len(fields) == 0 || len(fields) > 2 || t != strings.TrimSpace(t) {
+		return nil, invalid("")
+	}
+
+	r := new(big.Rat)
+	whole := ""
+	frac := fields[len(fields)-1]
+	if len(fields) == 2 {
+		whole = fields[0]
+	} else if !strings.Contains(frac, "/") {
+		whole, frac = frac, ""
+	}
+	if whole != "" {
+		w, ok := parseDigits(whole)
+		if !ok {
+			return nil, invalid("")
+		}
+		r.SetInt(w)
+	}
+	if frac != "" {
+		i := strings.IndexByte(frac, '/')
+		if i < 0 {
+			return nil, invalid("")
+		}
+		num, ok1 := parseDigits(frac[:i])
+		den, ok2 := parseDigits(frac[i+1:])
+		if !ok1 || !ok2 {
+			return nil, invalid("")
+		}
+		if den.Sign() == 0 {
+			return nil, invalid("zero denominator")
+		}
+		r.Add(r, new(big.Rat).SetFrac(num, den))
+	}
+	if negative {
+		r.Neg(r)
+	}
+	return r, nil
+}
+
+// parseDigits parses a non-empty string of ASCII digits
+func parseDigits(s string) (*big.Int, bool) {
+	if s == "" {
+		return nil, false
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return nil, false
+		}
+	}
+	return new(big.Int).SetString(s, 10)
+}
+
+// ParseRepeating parses a decimal whose repeating digits are in parentheses,
+// such as "0.(3)" for 1/3 or "-1.1(6)" for -7/6, and rounds it to the scale,
+// digits, with mode. Input without parentheses is parsed as a plain decimal,
+// surrounding whitespace is ignored like in ParseFraction.
+func ParseRepeating(s string, digits int, mode RoundingMode) (Decimal, error) {
+	r, err := parseRepeating(s)
+	if err != nil {
+		return Decimal{}, err
+	}
+	return newFromRat(r, digits, mode), nil
+}
+
+func parseRepeating(s string) (*big.Rat, error) {
+	invalid := fmt.Errorf("Invalid repeating decimal `%s'", s)
+	t := strings.TrimSpace(s)
+	negative := false
+	if strings.HasPrefix(t, "-") || strings.HasPrefix(t, "+") {
+		negative = t[0] == '-'
+		t = t[1:]
+	}
+	whole, frac := t, ""
+	if i := strings.IndexByte(t, '.'); i >= 0 {
+		whole, frac = t[:i], t[i+1:]
+		if whole == "" {
+			whole = "0"
+		}
+		if frac == "" {
+			return nil, invalid
+		}
+	}
+	fixed, repeating := frac, ""
+	if i := strings.IndexByte(frac, '('); i >= 0 {
+		if !strings.HasSuffix(frac, ")") {
+			return nil, invalid
+		}
+		fixed, repeating = frac[:i], frac[i+1:len(frac)-1]
+		if repeating == "" {
+			return nil, invalid
+		}
+	}
+
+	// whole.fixed(repeating) = (whole fixed repeating - whole fixed) / 10^len(fixed) (10^len(repeating) - 1)
+	a, ok := parseDigits(whole + fixed)
+	if !ok {
+		return nil, invalid
+	}
+	den := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(len(fixed))), nil)
+	if repeating != "" {
+		b, ok := parseDigits(whole + fixed + repeating)
+		if !ok {
+			return nil, invalid
+		}
+		a = b.Sub(b, a)
+		nines := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(len(repeating))), nil)
+		den.Mul(den, nines.Sub(nines, big.NewInt(1)))
+	}
+	r := new(big.Rat).SetFrac(a, den)
+	if negative {
+		r.Neg(r)
+	}
+	return r, nil
+}
+
+// ToFraction returns the fraction closest to d with a denominator of at most
+// maxDenominator, the best rational approximation found with continued
+// fractions, such as 22/7 for 3.14159 and maxDenominator 10. If d has an equal
+// fraction with such a denominator it is returned exactly. Use RatString for
+// display, it omits the denominator 1.
+func (d Decimal) ToFraction(maxDenominator int64) (*big.Rat, error) {
+	if maxDenominator < 1 {
+		return nil, fmt.Errorf("Invalid maximum denominator %d", maxDenominator)
+	}
+	if !d.native().IsFinite() {
+		return nil, errors.New("Cannot convert a non-finite value to a fraction")
+	}
+	x := d.native().Rat(nil)
+	limit := big.NewInt(maxDenominator)
+	if x.Denom().Cmp(limit) <= 0 {
+		return x, nil
+	}
+
+	// convergents p0/q0 and p1/q1 of the continued fraction of |x|
+	p0, q0, p1, q1 := big.NewInt(0), big.NewInt(1), big.NewInt(1), big.NewInt(0)
+	n, m := new(big.Int).Abs(x.Num()), new(big.Int).Set(x.Denom())
+	a, rem, q2 := new(big.Int), new(big.Int), new(big.Int)
+	for m.Sign() != 0 {
+		a.QuoRem(n, m, rem)
+		q2.Mul(a, q1).Add(q2, q0)
+		if q2.Cmp(limit) > 0 {
+			break
+		}
+		p2 := new(big.Int).Mul(a, p1)
+		p2.Add(p2, p0)
+		p0, q0, p1, q1 = p1, q1, p2, new(big.Int).Set(q2)
+		n, m = m, new(big.Int).Set(rem)
+	}
+
+	// the best approximation is the last convergent or the semiconvergent
+	// with the largest denominator within the limit
+	k := new(big.Int).Sub(limit, q0)
+	k.Quo(k, q1)
+	semi := new(big.Rat).SetFrac(
+		new(big.Int).Add(p0, new(big.Int).Mul(k, p1)),
+		new(big.Int).Add(q0, new(big.Int).Mul(k, q1)))
+	best := new(big.Rat).SetFrac(p1, q1)
+	abs := new(big.Rat).Abs(x)
+	if distance(semi, abs).Cmp(distance(best, abs)) < 0 {
+		best = semi
+	}
+	if x.Sign() < 0 {
+		best.Neg(best)
+	}
+	return best, nil
+}
+
+// distance returns |a - b|
+func distance(a, b *big.Rat) *big.Rat {
+	r := new(big.Rat).Sub(a, b)
+	return r.Abs(r)
+}
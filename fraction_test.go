@@ -0,0 +1,133 @@
+package decimal_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/talon-one/decimal"
+)
+
+func TestParseFraction(t *testing.T) {
+	testData := []struct {
+		input    string
+		digits   int
+		mode     decimal.RoundingMode
+		expected string
+	}{
+		{input: "3/8", digits: 3, expected: "0.375"},
+		{input: "3/8", digits: 2, expected: "0.38"},
+		{input: "3/8", digits: 2, mode: decimal.ToZero, expected: "0.37"},
+		{input: "1 1/2", digits: 2, expected: "1.50"},
+		{input: "  -2   3/4 ", digits: 2, expected: "-2.75"},
+		{input: "+1/3", digits: 4, expected: "0.3333"},
+		{input: "-2/3", digits: 4, expected: "-0.6667"},
+		{input: "2/3", digits: 4, mode: decimal.ToNegativeInf, expected: "0.6666"},
+		{input: "7", digits: 0, expected: "7"},
+		{input: "10/4", digits: 1, expected: "2.5"},
+		{input: "0/5", digits: 2, expected: "0"},
+		{input: "12345678901234567890/3", digits: 0, expected: "4115226300411522630"},
+	}
+	for i, j := range testData {
+		d, err := decimal.ParseFraction(j.input, j.digits, j.mode)
+		require.NoError(t, err, "At %d", i)
+		require.Equal(t, j.expected, d.String(), "At %d", i)
+	}
+}
+
+func TestParseFractionErrors(t *testing.T) {
+	testData := []struct {
+		input string
+		err   string
+	}{
+		{input: "", err: "Invalid fraction `'"},
+		{input: "1/0", err: "Invalid fraction `1/0': zero denominator"},
+		{input: "1 2", err: "Invalid fraction `1 2'"},
+		{input: "1/2/3", err: "Invalid fraction `1/2/3'"},
+		{input: "1 -1/2", err: "Invalid fraction `1 -1/2'"},
+		{input: "- 1/2", err: "Invalid fraction `- 1/2'"},
+		{input: "1 1/2 3", err: "Invalid fraction `1 1/2 3'"},
+		{input: "1.5/2", err: "Invalid fraction `1.5/2'"},
+		{input: "/2", err: "Invalid fraction `/2'"},
+		{input: "a/b", err: "Invalid fraction `a/b'"},
+	}
+	for i, j := range testData {
+		_, err := decimal.ParseFraction(j.input, 2, decimal.ToNearestEven)
+		require.EqualError(t, err, j.err, "At %d", i)
+	}
+}
+
+func TestParseRepeating(t *testing.T) {
+	testData := []struct {
+		input    string
+		digits   int
+		expected string
+	}{
+		{input: "0.(3)", digits: 5, expected: "0.33333"},
+		{input: "0.(6)", digits: 3, expected: "0.667"},
+		{input: "-1.1(6)", digits: 4, expected: "-1.1667"},
+		{input: "0.(142857)", digits: 8, expected: "0.14285714"},
+		{input: "0.(9)", digits: 2, expected: "1.00"},
+		{input: ".1(23)", digits: 6, expected: "0.123232"},
+		{input: "+12.5", digits: 2, expected: "12.50"},
+		{input: "3", digits: 0, expected: "3"},
+		{input: " 0.(3) ", digits: 2, expected: "0.33"},
+		{input: "\t-0.1(6)\n", digits: 3, expected: "-0.167"},
+	}
+	for i, j := range testData {
+		d, err := decimal.ParseRepeating(j.input, j.digits, decimal.ToNearestEven)
+		require.NoError(t, err, "At %d", i)
+		require.Equal(t, j.expected, d.String(), "At %d", i)
+	}
+
+	// 1.2(34) is 1222/990 exactly
+	a, err := decimal.ParseRepeating("1.2(34)", 30, decimal.ToNearestEven)
+	require.NoError(t, err)
+	b, err := decimal.ParseFraction("1222/990", 30, decimal.ToNearestEven)
+	require.NoError(t, err)
+	require.Equal(t, b.String(), a.String())
+
+	for i, s := range []string{"", ".", "1.", "0.()", "0.(3", "0.3)", "(3)", "0.(3)4", "0.1(2)(3)", "1e3", "0.(-3)"} {
+		_, err := decimal.ParseRepeating(s, 2, decimal.ToNearestEven)
+		require.EqualError(t, err, "Invalid repeating decimal `"+s+"'", "At %d", i)
+	}
+}
+
+func TestToFraction(t *testing.T) {
+	testData := []struct {
+		value          string
+		maxDenominator int64
+		expected       string
+	}{
+		{value: "3.14159", maxDenominator: 10, expected: "22/7"},
+		{value: "3.14159", maxDenominator: 100, expected: "311/99"},
+		{value: "3.14159265358979", maxDenominator: 1000, expected: "355/113"},
+		{value: "-3.14159", maxDenominator: 10, expected: "-22/7"},
+		{value: "0.375", maxDenominator: 8, expected: "3/8"},
+		{value: "0.375", maxDenominator: 7, expected: "2/5"},
+		{value: "0.3333", maxDenominator: 100, expected: "1/3"},
+		{value: "1.5", maxDenominator: 1, expected: "1/1"},
+		{value: "2.4", maxDenominator: 1, expected: "2/1"},
+		{value: "0.1", maxDenominator: 1, expected: "0/1"},
+		{value: "7", maxDenominator: 1, expected: "7/1"},
+		{value: "0", maxDenominator: 5, expected: "0/1"},
+		{value: "1E+3", maxDenominator: 5, expected: "1000/1"},
+	}
+	data := setup(func() []string {
+		values := make([]string, len(testData))
+		for i := range testData {
+			values[i] = testData[i].value
+		}
+		return values
+	}()...)
+	for i, j := range testData {
+		r, err := data.Decimals[i].ToFraction(j.maxDenominator)
+		require.NoError(t, err, "At %d", i)
+		require.Equal(t, j.expected, r.String(), "At %d", i)
+	}
+	data.VerifyIntegrity(t)
+
+	_, err := decimal.New(1, 0).ToFraction(0)
+	require.EqualError(t, err, "Invalid maximum denominator 0")
+	_, err = decimal.MustNewFromString("Infinity").ToFraction(10)
+	require.EqualError(t, err, "Cannot convert a non-finite value to a fraction")
+}
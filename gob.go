@@ -0,0 +1,13 @@
+package decimal
+
+// GobEncode implements the gob.GobEncoder interface with the format of
+// MarshalBinary
+func (d Decimal) GobEncode() ([]byte, error) {
+	return d.MarshalBinary()
+}
+
+// GobDecode implements the gob.GobDecoder interface, it rejects the input
+// UnmarshalBinary rejects
+func (d *Decimal) GobDecode(buf []byte) error {
+	return d.UnmarshalBinary(buf)
+}
@@ -0,0 +1,95 @@
+package decimal_test
+
+import (
+	"bytes"
+	"encoding/gob"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/talon-one/decimal"
+)
+
+var _ gob.GobEncoder = decimal.Decimal{}
+var _ gob.GobDecoder = (*decimal.Decimal)(nil)
+
+// gobRoundTrip encodes v with gob and decodes it into out
+func gobRoundTrip(t *testing.T, v, out interface{}) {
+	var buf bytes.Buffer
+	require.NoError(t, gob.NewEncoder(&buf).Encode(v))
+	require.NoError(t, gob.NewDecoder(&buf).Decode(out))
+}
+
+func TestGob(t *testing.T) {
+	data := setup("0", "-0", "1.50", "-123456789012345678901234567890.123", "1E+6", "Infinity")
+	for i, d := range data.Decimals {
+		var out decimal.Decimal
+		gobRoundTrip(t, d, &out)
+		require.Equal(t, d.GoString(), out.GoString(), "At %d", i)
+	}
+	data.VerifyIntegrity(t)
+
+	var out decimal.Decimal
+	gobRoundTrip(t, decimal.DivMode(decimal.Zero(), decimal.Zero(), 0, decimal.ToNearestEven), &out)
+	require.True(t, out.IsNaN())
+}
+
+func TestGobNested(t *testing.T) {
+	type Line struct {
+		Price    decimal.Decimal
+		Discount *decimal.Decimal
+	}
+	type Rule struct {
+		Name       string
+		Threshold  decimal.Decimal
+		Lines      []Line
+		Totals     []decimal.Decimal
+		ByCurrency map[string]decimal.Decimal
+		Limits     map[string][]decimal.Decimal
+	}
+	discount := decimal.MustNewFromString("0.10")
+	in := Rule{
+		Name:      "bulk",
+		Threshold: decimal.MustNewFromString("99.90"),
+		Lines: []Line{
+			{Price: decimal.MustNewFromString("12.50"), Discount: &discount},
+			{Price: decimal.MustNewFromString("-0.005")},
+		},
+		Totals:     []decimal.Decimal{decimal.MustNewFromString("1E+3"), decimal.Zero(), {}},
+		ByCurrency: map[string]decimal.Decimal{"EUR": decimal.MustNewFromString("1.10"), "JPY": decimal.New(150, 0)},
+		Limits:     map[string][]decimal.Decimal{"daily": {decimal.MustNewFromString("500.00")}},
+	}
+
+	var out Rule
+	gobRoundTrip(t, in, &out)
+	require.Equal(t, "bulk", out.Name)
+	require.Equal(t, "99.90", out.Threshold.String())
+	require.Len(t, out.Lines, 2)
+	require.Equal(t, "12.50", out.Lines[0].Price.String())
+	require.NotNil(t, out.Lines[0].Discount)
+	require.Equal(t, "0.10", out.Lines[0].Discount.String())
+	require.Equal(t, "-0.005", out.Lines[1].Price.String())
+	require.Nil(t, out.Lines[1].Discount)
+	require.Len(t, out.Totals, 3)
+	require.Equal(t, in.Totals[0].GoString(), out.Totals[0].GoString())
+	require.Equal(t, "0", out.Totals[1].String())
+	require.Equal(t, "0", out.Totals[2].String())
+	require.Len(t, out.ByCurrency, 2)
+	require.Equal(t, "1.10", out.ByCurrency["EUR"].String())
+	require.Equal(t, "150", out.ByCurrency["JPY"].String())
+	require.Equal(t, "500.00", out.Limits["daily"][0].String())
+}
+
+func TestGobDecodeLimits(t *testing.T) {
+	// 1E+20000000, rejected like the text "1E+20000000"
+	d := decimal.New(7, 0)
+	require.EqualError(t, d.GobDecode([]byte{1, 0, 0x80, 0xb4, 0x89, 0x13, 1}), "Invalid binary decimal: exponent exceeds 6144")
+	require.Equal(t, "7", d.String())
+
+	type Entry struct {
+		Value decimal.Decimal
+	}
+	var buf bytes.Buffer
+	require.NoError(t, gob.NewEncoder(&buf).Encode(Entry{Value: decimal.MustNewFromString("1E+6145")}))
+	var out Entry
+	require.EqualError(t, gob.NewDecoder(&buf).Decode(&out), "Invalid binary decimal: exponent exceeds 6144")
+}
@@ -0,0 +1,123 @@
+package decimal
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// localeData holds the number symbols, grouping and currency patterns of the
+// supported locales, taken from the Unicode CLDR.
+//
+//go:embed locales.json
+var localeData []byte
+
+// Locale describes how numbers and amounts are written in a region
+type Locale struct {
+	// Tag is the BCP 47 language tag, such as "de-CH"
+	Tag string `json:"tag"`
+	// Decimal separates the integer from the fractional digits
+	Decimal string `json:"decimal"`
+	// Group separates the groups of integer digits
+	Group string `json:"group"`
+	// Grouping holds the size of the lowest group and of all higher groups,
+	// [3, 3] for 1,234,567 and [3, 2] for the Indian 12,34,567
+	Grouping [2]int `json:"grouping"`
+	// MinimumGrouping is the number of digits the highest group must have for
+	// the integer to be grouped, with 2 1234 stays ungrouped but 12,345 does not
+	MinimumGrouping int `json:"minimumGrouping"`
+	// Minus is the minus sign
+	Minus string `json:"minus"`
+	// CurrencyPattern places the currency symbol ¤ relative to the number #,
+	// such as "¤#" for $1.00 or "# ¤" for 1,00 €
+	CurrencyPattern string `json:"currencyPattern"`
+	// Symbols overrides the default currency symbols in the locale
+	Symbols map[string]string `json:"symbols"`
+}
+
+// Symbol returns the currency symbol for code in l, it is the code itself if
+// there is no symbol
+func (l Locale) Symbol(code string) string {
+	if s, ok := l.Symbols[code]; ok {
+		return s
+	}
+	locales.load()
+	if s, ok := locales.symbols[code]; ok {
+		return s
+	}
+	return code
+}
+
+type localeRegistry struct {
+	once     sync.Once
+	byTag    map[string]Locale
+	defaults map[string]string
+	symbols  map[string]string
+}
+
+var locales localeRegistry
+
+func (r *localeRegistry) load() {
+	r.once.Do(func() {
+		var data struct {
+			Symbols  map[string]string `json:"symbols"`
+			Defaults map[string]string `json:"defaults"`
+			Locales  []Locale          `json:"locales"`
+		}
+		if err := json.Unmarshal(localeData, &data); err != nil {
+			panic(fmt.Sprintf("decimal: invalid embedded locale data: %v", err))
+		}
+		r.byTag = make(map[string]Locale, len(data.Locales))
+		for _, l := range data.Locales {
+			r.byTag[strings.ToLower(l.Tag)] = l
+		}
+		r.defaults = data.Defaults
+		r.symbols = data.Symbols
+	})
+}
+
+// LookupLocale returns the locale with the language tag, such as "de-CH",
+// "de_CH" or "de". Case is ignored. A tag with an unknown region falls back
+// to the main region of the language, "de-LU" is written like "de-DE". The
+// locale is a copy, changing it does not affect later lookups.
+func LookupLocale(tag string) (Locale, bool) {
+	locales.load()
+	tag = strings.ToLower(strings.ReplaceAll(strings.TrimSpace(tag), "_", "-"))
+	if l, ok := locales.byTag[tag]; ok {
+		return l.clone(), true
+	}
+	language := tag
+	if i := strings.IndexByte(tag, '-'); i >= 0 {
+		language = tag[:i]
+	}
+	if def, ok := locales.defaults[language]; ok {
+		return locales.byTag[strings.ToLower(def)].clone(), true
+	}
+	return Locale{}, false
+}
+
+// clone returns l with its own copy of the symbols
+func (l Locale) clone() Locale {
+	if l.Symbols != nil {
+		symbols := make(map[string]string, len(l.Symbols))
+		for code, symbol := range l.Symbols {
+			symbols[code] = symbol
+		}
+		l.Symbols = symbols
+	}
+	return l
+}
+
+// Locales returns the tags of all supported locales in order
+func Locales() []string {
+	locales.load()
+	tags := make([]string, 0, len(locales.byTag))
+	for _, l := range locales.byTag {
+		tags = append(tags, l.Tag)
+	}
+	sort.Strings(tags)
+	return tags
+}
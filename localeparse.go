@@ -0,0 +1,314 @@
+package decimal
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// LocaleParseOptions configures ParseLocale and ParseMoneyLocale
+type LocaleParseOptions struct {
+	// Currency, if set, is the only currency whose symbol or code is accepted.
+	// It is the currency of amounts without symbol in ParseMoneyLocale.
+	Currency string
+	// RequireCurrency fails for amounts without currency symbol or code
+	RequireCurrency bool
+}
+
+// ParseLocale parses an amount as users type it in the locale with the tag,
+// such as "1.234,56" in de-DE or "$1,234.56" and "(45.00)" in en-US. It
+// accepts the grouping and decimal separators of the locale, a currency symbol
+// or code before or after the number, leading and trailing signs, accounting
+// parentheses for negative amounts and regular, non-breaking and narrow
+// spaces. Group separators must be placed as the locale groups digits, the
+// errors give the position of the offending character, counted in characters
+// from 1.
+func ParseLocale(s string, tag string, opts LocaleParseOptions) (Decimal, error) {
+	d, _, err := parseLocale(s, tag, opts)
+	return d, err
+}
+
+// ParseMoneyLocale is like ParseLocale but returns money in the currency of
+// the symbol or code in s, or opts.Currency if there is none.
+func ParseMoneyLocale(s string, tag string, opts LocaleParseOptions) (Money, error) {
+	d, currency, err := parseLocale(s, tag, opts)
+	if err != nil {
+		return Money{}, err
+	}
+	if currency == "" {
+		currency = opts.Currency
+	}
+	if currency == "" {
+		return Money{}, fmt.Errorf("Invalid amount `%s': no currency", s)
+	}
+	return NewMoney(d, currency)
+}
+
+type localeParser struct {
+	input    string
+	runes    []rune
+	locale   Locale
+	symbols  []string
+	bySymbol map[string]string
+}
+
+func (p *localeParser) errorf(pos int, format string, args ...interface{}) error {
+	return fmt.Errorf("Invalid amount `%s': %s at position %d", p.input, fmt.Sprintf(format, args...), pos+1)
+}
+
+func isSpace(r rune) bool {
+	return r == ' ' || r == '\u00a0' || r == '\u202f' || r == '\t'
+}
+
+// isGroup reports whether r is the group separator of the locale. Spaces
+// match each other and ' matches ’ as users type them interchangeably.
+func (p *localeParser) isGroup(r rune) bool {
+	group := []rune(p.locale.Group)
+	if len(group) != 1 {
+		return false
+	}
+	switch {
+	case r == group[0]:
+		return true
+	case isSpace(group[0]):
+		return isSpace(r)
+	case group[0] == '’':
+		return r == '\''
+	}
+	return false
+}
+
+func (p *localeParser) isMinus(r rune) bool {
+	return r == '-' || r == '\u2212' || string(r) == p.locale.Minus
+}
+
+// currencyAt returns the currency and the length of the symbol or code at i
+// reading forward, or ending at i reading backward
+func (p *localeParser) currencyAt(i int, backward bool) (string, int) {
+	for _, symbol := range p.symbols {
+		s := []rune(symbol)
+		start := i
+		if backward {
+			start = i - len(s) + 1
+		}
+		if start < 0 || start+len(s) > len(p.runes) || string(p.runes[start:start+len(s)]) != symbol {
+			continue
+		}
+		return p.bySymbol[symbol], len(s)
+	}
+	start := i
+	if backward {
+		start = i - 2
+	}
+	if start < 0 || start+3 > len(p.runes) {
+		return "", 0
+	}
+	code := string(p.runes[start : start+3])
+	for _, r := range code {
+		if !unicode.IsLetter(r) {
+			return "", 0
+		}
+	}
+	if c, ok := LookupCurrency(code); ok {
+		return c.Code, 3
+	}
+	return "", 0
+}
+
+func parseLocale(s string, tag string, opts LocaleParseOptions) (Decimal, string, error) {
+	l, ok := LookupLocale(tag)
+	if !ok {
+		return Decimal{}, "", fmt.Errorf("Unknown locale `%s'", tag)
+	}
+	p := localeParser{input: s, runes: []rune(s), locale: l, bySymbol: make(map[string]string)}
+	locales.load()
+	for code, symbol := range locales.symbols {
+		p.bySymbol[symbol] = code
+	}
+	for code, symbol := range l.Symbols {
+		p.bySymbol[symbol] = code
+	}
+	for symbol := range p.bySymbol {
+		p.symbols = append(p.symbols, symbol)
+	}
+	// longer symbols first so US$ wins over $
+	sort.Slice(p.symbols, func(i, j int) bool {
+		if len(p.symbols[i]) != len(p.symbols[j]) {
+			return len(p.symbols[i]) > len(p.symbols[j])
+		}
+		return p.symbols[i] < p.symbols[j]
+	})
+
+	lo, hi := 0, len(p.runes)-1
+	negative, signed, parens := false, false, false
+	currency := ""
+	setCurrency := func(code string, pos int) error {
+		if currency != "" {
+			return p.errorf(pos, "second currency")
+		}
+		if opts.Currency != "" && !strings.EqualFold(code, opts.Currency) {
+			return p.errorf(pos, "currency %s instead of %s", code, strings.ToUpper(opts.Currency))
+		}
+		currency = code
+		return nil
+	}
+	setSign := func(minus bool, pos int) error {
+		if signed || parens {
+			return p.errorf(pos, "second sign")
+		}
+		signed, negative = true, minus
+		return nil
+	}
+
+	// leading and trailing spaces, signs, currencies and parentheses
+	for lo <= hi {
+		r := p.runes[lo]
+		switch {
+		case isSpace(r):
+			lo++
+		case r == '(' && p.runes[hi] == ')' && !parens:
+			if signed {
+				return Decimal{}, "", p.errorf(lo, "second sign")
+			}
+			parens, negative = true, true
+			lo++
+			hi--
+		case r == '+' || p.isMinus(r):
+			if err := setSign(r != '+', lo); err != nil {
+				return Decimal{}, "", err
+			}
+			lo++
+		default:
+			code, n := p.currencyAt(lo, false)
+			if n == 0 {
+				goto trailing
+			}
+			if err := setCurrency(code, lo); err != nil {
+				return Decimal{}, "", err
+			}
+			lo += n
+		}
+	}
+trailing:
+	for hi >= lo {
+		r := p.runes[hi]
+		switch {
+		case isSpace(r):
+			hi--
+		case r == '+' || p.isMinus(r):
+			if err := setSign(r != '+', hi); err != nil {
+				return Decimal{}, "", err
+			}
+			hi--
+		default:
+			code, n := p.currencyAt(hi, true)
+			if n == 0 {
+				goto number
+			}
+			if err := setCurrency(code, hi-n+1); err != nil {
+				return Decimal{}, "", err
+			}
+			hi -= n
+		}
+	}
+number:
+	if opts.RequireCurrency && currency == "" {
+		return Decimal{}, "", fmt.Errorf("Invalid amount `%s': no currency", s)
+	}
+	if lo > hi {
+		return Decimal{}, "", fmt.Errorf("Invalid amount `%s': no digits", s)
+	}
+
+	var integer, fraction strings.Builder
+	var groups []int // positions of the group separators
+	var groupSizes []int
+	digitsInGroup := 0
+	point := -1
+	decimal := []rune(p.locale.Decimal)
+	for i := lo; i <= hi; i++ {
+		r := p.runes[i]
+		switch {
+		case r >= '0' && r <= '9':
+			if point >= 0 {
+				fraction.WriteRune(r)
+			} else {
+				integer.WriteRune(r)
+				digitsInGroup++
+			}
+		case len(decimal) == 1 && r == decimal[0]:
+			if point >= 0 {
+				return Decimal{}, "", p.errorf(i, "second decimal separator")
+			}
+			point = i
+		case p.isGroup(r):
+			if point >= 0 {
+				return Decimal{}, "", p.errorf(i, "group separator after the decimal separator")
+			}
+			if digitsInGroup == 0 {
+				return Decimal{}, "", p.errorf(i, "misplaced group separator")
+			}
+			groups = append(groups, i)
+			groupSizes = append(groupSizes, digitsInGroup)
+			digitsInGroup = 0
+		default:
+			return Decimal{}, "", p.errorf(i, "unexpected %q", r)
+		}
+	}
+	if integer.Len() == 0 && fraction.Len() == 0 {
+		return Decimal{}, "", fmt.Errorf("Invalid amount `%s': no digits", s)
+	}
+	if len(groups) > 0 {
+		if err := p.checkGroups(groups, append(groupSizes, digitsInGroup), point, hi); err != nil {
+			return Decimal{}, "", err
+		}
+	}
+
+	str := integer.String()
+	if str == "" {
+		str = "0"
+	}
+	if fraction.Len() > 0 {
+		str += "." + fraction.String()
+	}
+	if negative {
+		str = "-" + str
+	}
+	d, err := NewFromString(str)
+	if err != nil {
+		return Decimal{}, "", fmt.Errorf("Invalid amount `%s': %v", s, err)
+	}
+	return d, currency, nil
+}
+
+// checkGroups validates the sizes of the digit groups, the last entry of
+// sizes is the group after the last separator at the positions groups
+func (p *localeParser) checkGroups(groups []int, sizes []int, point, hi int) error {
+	primary, secondary := p.locale.Grouping[0], p.locale.Grouping[1]
+	if primary <= 0 {
+		return p.errorf(groups[0], "unexpected group separator")
+	}
+	if secondary <= 0 {
+		secondary = primary
+	}
+	last := len(sizes) - 1
+	if sizes[last] != primary {
+		if sizes[last] == 0 {
+			end := point
+			if end < 0 {
+				end = hi + 1
+			}
+			return p.errorf(end-1, "misplaced group separator")
+		}
+		return p.errorf(groups[last-1], "misplaced group separator")
+	}
+	for i := last - 1; i >= 1; i-- {
+		if sizes[i] != secondary {
+			return p.errorf(groups[i-1], "misplaced group separator")
+		}
+	}
+	if sizes[0] > secondary {
+		return p.errorf(groups[0], "misplaced group separator")
+	}
+	return nil
+}
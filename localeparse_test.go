@@ -0,0 +1,109 @@
+package decimal_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/talon-one/decimal"
+)
+
+func TestParseLocale(t *testing.T) {
+	testData := []struct {
+		locale   string
+		input    string
+		expected string
+	}{
+		{locale: "de-DE", input: "1.234,56", expected: "1234.56"},
+		{locale: "fr-FR", input: "1 234,56", expected: "1234.56"},
+		{locale: "fr-FR", input: "1\u202f234,56\u00a0€", expected: "1234.56"},
+		{locale: "fr-FR", input: "1\u00a0234,56", expected: "1234.56"},
+		{locale: "en-US", input: "$1,234.56", expected: "1234.56"},
+		{locale: "en-US", input: "(45.00)", expected: "-45.00"},
+		{locale: "en-US", input: "($45.00)", expected: "-45.00"},
+		{locale: "en-US", input: "-$1,234.50", expected: "-1234.50"},
+		{locale: "en-US", input: "$-1,234.50", expected: "-1234.50"},
+		{locale: "en-US", input: "45.00-", expected: "-45.00"},
+		{locale: "en-US", input: "+7", expected: "7"},
+		{locale: "en-US", input: " USD 12.5 ", expected: "12.5"},
+		{locale: "en-US", input: "12.5 EUR", expected: "12.5"},
+		{locale: "en-US", input: "1234567", expected: "1234567"},
+		{locale: "en-US", input: ".5", expected: "0.5"},
+		{locale: "en-US", input: "5.", expected: "5"},
+		{locale: "en-IN", input: "₹12,34,567.00", expected: "1234567.00"},
+		{locale: "de-CH", input: "CHF 1’234.50", expected: "1234.50"},
+		{locale: "de-CH", input: "1'234.50", expected: "1234.50"},
+		{locale: "sv-SE", input: "\u22125\u00a0000,00 kr", expected: "-5000.00"},
+		{locale: "da-DK", input: "10,00 kr.", expected: "10.00"},
+		{locale: "es-ES", input: "1.234,00 €", expected: "1234.00"},
+		{locale: "de_at", input: "€ 3,50", expected: "3.50"},
+	}
+	for i, j := range testData {
+		d, err := decimal.ParseLocale(j.input, j.locale, decimal.LocaleParseOptions{})
+		require.NoError(t, err, "At %d", i)
+		require.Equal(t, j.expected, d.String(), "At %d", i)
+	}
+}
+
+func TestParseLocaleErrors(t *testing.T) {
+	testData := []struct {
+		locale string
+		input  string
+		opts   decimal.LocaleParseOptions
+		err    string
+	}{
+		{locale: "en-US", input: "1,23,456", err: "Invalid amount `1,23,456': misplaced group separator at position 2"},
+		{locale: "en-US", input: "1,2345", err: "Invalid amount `1,2345': misplaced group separator at position 2"},
+		{locale: "en-US", input: "1234,567", err: "Invalid amount `1234,567': misplaced group separator at position 5"},
+		{locale: "en-US", input: "1,234,", err: "Invalid amount `1,234,': misplaced group separator at position 6"},
+		{locale: "en-US", input: ",123", err: "Invalid amount `,123': misplaced group separator at position 1"},
+		{locale: "en-US", input: "1.234,56", err: "Invalid amount `1.234,56': group separator after the decimal separator at position 6"},
+		{locale: "de-DE", input: "1,2,3", err: "Invalid amount `1,2,3': second decimal separator at position 4"},
+		{locale: "en-US", input: "12a", err: "Invalid amount `12a': unexpected 'a' at position 3"},
+		{locale: "en-US", input: "-(5)", err: "Invalid amount `-(5)': second sign at position 2"},
+		{locale: "en-US", input: "(-5)", err: "Invalid amount `(-5)': second sign at position 2"},
+		{locale: "en-US", input: "-5-", err: "Invalid amount `-5-': second sign at position 3"},
+		{locale: "en-US", input: "$5 EUR", err: "Invalid amount `$5 EUR': second currency at position 4"},
+		{locale: "en-US", input: "$5", opts: decimal.LocaleParseOptions{Currency: "EUR"}, err: "Invalid amount `$5': currency USD instead of EUR at position 1"},
+		{locale: "en-US", input: "5", opts: decimal.LocaleParseOptions{RequireCurrency: true}, err: "Invalid amount `5': no currency"},
+		{locale: "en-US", input: "$", err: "Invalid amount `$': no digits"},
+		{locale: "en-US", input: ".", err: "Invalid amount `.': no digits"},
+		{locale: "xx", input: "5", err: "Unknown locale `xx'"},
+	}
+	for i, j := range testData {
+		_, err := decimal.ParseLocale(j.input, j.locale, j.opts)
+		require.EqualError(t, err, j.err, "At %d", i)
+	}
+}
+
+func TestParseMoneyLocale(t *testing.T) {
+	m, err := decimal.ParseMoneyLocale("$10.00", "en-CA", decimal.LocaleParseOptions{})
+	require.NoError(t, err)
+	require.Equal(t, "10.00 CAD", m.String())
+
+	m, err = decimal.ParseMoneyLocale("US$10.00", "en-CA", decimal.LocaleParseOptions{})
+	require.NoError(t, err)
+	require.Equal(t, "10.00 USD", m.String())
+
+	m, err = decimal.ParseMoneyLocale("1.234,5", "de-DE", decimal.LocaleParseOptions{Currency: "EUR"})
+	require.NoError(t, err)
+	require.Equal(t, "1234.5 EUR", m.String())
+
+	_, err = decimal.ParseMoneyLocale("12", "de-DE", decimal.LocaleParseOptions{})
+	require.EqualError(t, err, "Invalid amount `12': no currency")
+}
+
+func TestParseLocaleRoundTrip(t *testing.T) {
+	values := []string{"1234.50 EUR", "-1234567.89 USD", "0.05 CHF", "1234567 JPY", "-12345678.90 INR"}
+	for _, tag := range decimal.Locales() {
+		for _, sign := range []decimal.SignStyle{decimal.SignAuto, decimal.SignAccounting} {
+			f := decimal.MustNewFormatter(tag).WithSign(sign)
+			for i, v := range values {
+				m := decimal.MustParseMoney(v)
+				s := f.FormatMoney(m)
+				parsed, err := decimal.ParseMoneyLocale(s, tag, decimal.LocaleParseOptions{})
+				require.NoError(t, err, "At %s %d: %s", tag, i, s)
+				require.True(t, m.Equals(parsed), "At %s %d: %s != %s", tag, i, s, parsed)
+			}
+		}
+	}
+}
@@ -2,14 +2,42 @@ package decimal
 
 import "bytes"
 
+// AppendText appends d formatted like String to dst and returns the extended
+// buffer. It does not allocate if dst has enough capacity and the coefficient
+// of d fits in 64 bits.
+func (d Decimal) AppendText(dst []byte) []byte {
+	x := d.native()
+	if x.IsFinite() && x.Sign() == 0 {
+		return append(dst, '0')
+	}
+	places := x.Scale()
+	if places < 0 {
+		places = 0
+	}
+	return d.appendFixed(dst, places, ToNearestEven)
+}
+
+// AppendJSON appends d as JSON to dst and returns the extended buffer. Finite
+// values are written as numbers, NaN and Infinity as the strings "NaN",
+// "Infinity" and "-Infinity" which UnmarshalJSON accepts.
+func (d Decimal) AppendJSON(dst []byte) []byte {
+	if d.native().IsFinite() {
+		return d.AppendText(dst)
+	}
+	dst = append(dst, '"')
+	dst = d.AppendText(dst)
+	return append(dst, '"')
+}
+
 // MarshalText implements the encoding.TextMarshaler interface for serialization
 func (d Decimal) MarshalText() ([]byte, error) {
-	return []byte(d.String()), nil
+	return d.AppendText(nil), nil
 }
 
-// UnmarshalText implements the encoding.TextUnmarshaler interface for deserialization
+// UnmarshalText implements the encoding.TextUnmarshaler interface for deserialization,
+// it parses buf with the DefaultParser
 func (d *Decimal) UnmarshalText(buf []byte) error {
-	tmp, err := NewFromString(string(buf))
+	tmp, err := DefaultParser().ParseBytes(buf)
 	if err != nil {
 		return err
 	}
@@ -19,7 +47,7 @@ func (d *Decimal) UnmarshalText(buf []byte) error {
 
 // MarshalJSON implements the json.Marshaler interface for serialization
 func (d Decimal) MarshalJSON() ([]byte, error) {
-	return d.MarshalText()
+	return d.AppendJSON(nil), nil
 }
 
 // UnmarshalJSON implements the json.Unmarshaler interface for deserialization
@@ -35,3 +35,118 @@ func TestUnmarshalText(t *testing.T) {
 	require.NoError(t, d.UnmarshalJSON([]byte(`123.456`)))
 	require.Equal(t, "123.456", d.String())
 }
+
+func TestAppendText(t *testing.T) {
+	values := []string{"0", "-0", "0.000", "0E+3", "1", "-1.50", "123.456", "1E+6", "-1.5E+3", "1E-10",
+		"12345678901234567890.123456789", "-98765432109876543210", "Infinity", "-Infinity"}
+	for i, v := range values {
+		d, err := NewFromString(v)
+		require.NoError(t, err, "At %d", i)
+		require.Equal(t, d.String(), string(d.AppendText(nil)), "At %d", i)
+		require.Equal(t, "x"+d.String(), string(d.AppendText([]byte("x"))), "At %d", i)
+	}
+	require.Equal(t, "0", string(Decimal{}.AppendText(nil)))
+	require.Equal(t, "NaN", string(DivMode(Zero(), Zero(), 0, ToNearestEven).AppendText(nil)))
+}
+
+func TestAppendJSON(t *testing.T) {
+	testData := []struct {
+		value    Decimal
+		expected string
+	}{
+		{value: MustNewFromString("-1.50"), expected: `-1.50`},
+		{value: MustNewFromString("1E+3"), expected: `1000`},
+		{value: MustNewFromString("Infinity"), expected: `"Infinity"`},
+		{value: MustNewFromString("-Infinity"), expected: `"-Infinity"`},
+		{value: DivMode(Zero(), Zero(), 0, ToNearestEven), expected: `"NaN"`},
+	}
+	for i, j := range testData {
+		require.Equal(t, j.expected, string(j.value.AppendJSON(nil)), "At %d", i)
+		buf, err := j.value.MarshalJSON()
+		require.NoError(t, err, "At %d", i)
+		require.Equal(t, j.expected, string(buf), "At %d", i)
+	}
+
+	var d Decimal
+	require.NoError(t, d.UnmarshalJSON([]byte(`"-Infinity"`)))
+	require.Equal(t, "-Infinity", d.String())
+}
+
+func TestParseBytes(t *testing.T) {
+	values := []string{"0", "-0", "-0.00", "1.", ".5", "+12.50", "-1.5E+3", "1e-10", "999999999999999999",
+		"1000000000000000000", "-12345678901234567890.123456789", "000123.4500", "Inf", "-infinity"}
+	for i, v := range values {
+		expected, err := NewFromString(v)
+		require.NoError(t, err, "At %d", i)
+		d, err := ParseBytes([]byte(v))
+		require.NoError(t, err, "At %d", i)
+		require.Equal(t, expected.String(), d.String(), "At %d", i)
+		require.Equal(t, expected.native().Scale(), d.native().Scale(), "At %d", i)
+		require.Equal(t, expected.native().Signbit(), d.native().Signbit(), "At %d", i)
+	}
+
+	for i, v := range []string{"", "-", ".", "1..2", "1e", "1e+", "abc", "1 ", "NaN"} {
+		_, err := ParseBytes([]byte(v))
+		require.EqualError(t, err, "Invalid decimal", "At %d", i)
+	}
+}
+
+func TestMarshalAllocations(t *testing.T) {
+	d := MustNewFromString("-1234.5678")
+	buf := make([]byte, 0, 64)
+	allocs := testing.AllocsPerRun(100, func() {
+		buf = d.AppendText(buf[:0])
+		buf = d.AppendJSON(buf)
+	})
+	require.Equal(t, "-1234.5678-1234.5678", string(buf))
+	require.Zero(t, allocs)
+
+	input := []byte("-1234.5678")
+	allocs = testing.AllocsPerRun(100, func() {
+		_ = d.UnmarshalText(input)
+	})
+	// the *decimal.Big of the result
+	require.Equal(t, 1.0, allocs)
+}
+
+var benchmarkDecimal = MustNewFromString("-1234567.8901")
+
+func BenchmarkMarshalText(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_, _ = benchmarkDecimal.MarshalText()
+	}
+}
+
+func BenchmarkAppendText(b *testing.B) {
+	b.ReportAllocs()
+	buf := make([]byte, 0, 64)
+	for i := 0; i < b.N; i++ {
+		buf = benchmarkDecimal.AppendText(buf[:0])
+	}
+}
+
+func BenchmarkAppendJSON(b *testing.B) {
+	b.ReportAllocs()
+	buf := make([]byte, 0, 64)
+	for i := 0; i < b.N; i++ {
+		buf = benchmarkDecimal.AppendJSON(buf[:0])
+	}
+}
+
+func BenchmarkUnmarshalText(b *testing.B) {
+	b.ReportAllocs()
+	input := []byte("-1234567.8901")
+	var d Decimal
+	for i := 0; i < b.N; i++ {
+		_ = d.UnmarshalText(input)
+	}
+}
+
+func BenchmarkUnmarshalTextSetString(b *testing.B) {
+	b.ReportAllocs()
+	input := []byte("-1234567.8901")
+	for i := 0; i < b.N; i++ {
+		_, _ = NewFromString(string(input))
+	}
+}
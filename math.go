@@ -0,0 +1,93 @@
+package decimal
+
+import (
+	"github.com/ericlagergren/decimal"
+	"github.com/ericlagergren/decimal/math"
+)
+
+var (
+	half = New(5, 1)
+	two  = New(2, 0)
+)
+
+// Pow raises the instance to the power of n
+func (dec Decimal) Pow(n Decimal) Decimal {
+	x, y := dec.native(), n.native()
+	if x.Cmp(half.native()) == 0 && y.Cmp(half.native()) != 0 {
+		// math.Pow mistakes any power of 0.5 for its square root,
+		// 0.5 ** y is computed as 2 ** -y instead
+		x = two.native()
+		y = decimal.New(0, 0).Neg(y)
+	}
+	z := decimal.New(0, 0)
+	math.Pow(z, x, y)
+	dec.native().Set(z)
+	return Decimal{dec.native()}
+}
+
+// Pow raises a to the power of n and returns a new decimal instance
+// a and n will not be modified
+func Pow(a Decimal, n Decimal) Decimal {
+	d := NewFromDecimal(a)
+	return d.Pow(n)
+}
+
+// Sqrt sets the instance to its square root
+func (dec Decimal) Sqrt() Decimal {
+	z := decimal.New(0, 0)
+	math.Sqrt(z, dec.native())
+	dec.native().Set(z)
+	return Decimal{dec.native()}
+}
+
+// Sqrt returns the square root of a as a new decimal instance
+// a will not be modified
+func Sqrt(a Decimal) Decimal {
+	d := NewFromDecimal(a)
+	return d.Sqrt()
+}
+
+// Exp sets the instance to e raised to its power
+func (dec Decimal) Exp() Decimal {
+	z := decimal.New(0, 0)
+	math.Exp(z, dec.native())
+	dec.native().Set(z)
+	return Decimal{dec.native()}
+}
+
+// Exp returns e raised to the power of a as a new decimal instance
+// a will not be modified
+func Exp(a Decimal) Decimal {
+	d := NewFromDecimal(a)
+	return d.Exp()
+}
+
+// Log sets the instance to its natural logarithm
+func (dec Decimal) Log() Decimal {
+	z := decimal.New(0, 0)
+	math.Log(z, dec.native())
+	dec.native().Set(z)
+	return Decimal{dec.native()}
+}
+
+// Log returns the natural logarithm of a as a new decimal instance
+// a will not be modified
+func Log(a Decimal) Decimal {
+	d := NewFromDecimal(a)
+	return d.Log()
+}
+
+// Log10 sets the instance to its common logarithm
+func (dec Decimal) Log10() Decimal {
+	z := decimal.New(0, 0)
+	math.Log10(z, dec.native())
+	dec.native().Set(z)
+	return Decimal{dec.native()}
+}
+
+// Log10 returns the common logarithm of a as a new decimal instance
+// a will not be modified
+func Log10(a Decimal) Decimal {
+	d := NewFromDecimal(a)
+	return d.Log10()
+}
@@ -0,0 +1,49 @@
+package decimal_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/talon-one/decimal"
+)
+
+func TestPow(t *testing.T) {
+	testData := []struct {
+		x        string
+		y        string
+		expected string
+	}{
+		{x: "2", y: "10", expected: "1024"},
+		{x: "1.05", y: "2", expected: "1.1025"},
+		{x: "2", y: "-2", expected: "0.25"},
+		{x: "0.5", y: "2", expected: "0.25"},
+		{x: "0.5", y: "0.5", expected: "0.7071067811865475"},
+		{x: "4", y: "0.5", expected: "2"},
+	}
+	for i, j := range testData {
+		data := setup(j.x, j.y)
+		output := decimal.Pow(data.Decimals[0], data.Decimals[1])
+		require.True(t, output.Equals(decimal.MustNewFromString(j.expected)), "At %d: %s ≠ %s", i, j.expected, output)
+		data.VerifyIntegrity(t)
+	}
+}
+
+func TestSqrt(t *testing.T) {
+	data := setup("2")
+	require.Equal(t, "1.414213562373095", decimal.Sqrt(data.Decimals[0]).String())
+	data.VerifyIntegrity(t)
+
+	require.Equal(t, "1.414213562373095", data.Decimals[0].Sqrt().String())
+	data.StringRepresentations[0] = "1.414213562373095"
+	data.VerifyIntegrity(t)
+}
+
+func TestExpAndLog(t *testing.T) {
+	data := setup("1", "10", "1000")
+	require.Equal(t, "2.718281828459045", decimal.Exp(data.Decimals[0]).String())
+	require.Equal(t, "2.302585092994046", decimal.Log(data.Decimals[1]).String())
+	require.Equal(t, "3", decimal.Log10(data.Decimals[2]).String())
+	data.VerifyIntegrity(t)
+
+	require.True(t, decimal.Log(decimal.Exp(decimal.NewFromInt(3))).AlmostEquals(decimal.NewFromInt(3), decimal.New(1, 14)))
+}
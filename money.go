@@ -0,0 +1,255 @@
+package decimal
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Money is a decimal amount in a currency. Arithmetic between amounts of
+// different currencies fails. Money values are immutable, all operations
+// return a new instance.
+type Money struct {
+	amount   Decimal
+	currency string
+}
+
+// NewMoney returns amount in currency, currency is an ISO 4217 code like "EUR"
+// or the code of a currency added with RegisterCurrency.
+func NewMoney(amount Decimal, currency string) (Money, error) {
+	c, ok := LookupCurrency(currency)
+	if !ok {
+		return Money{}, fmt.Errorf("Unknown currency `%s'", currency)
+	}
+	return Money{amount: NewFromDecimal(amount), currency: c.Code}, nil
+}
+
+// MustNewMoney is like NewMoney but panics if currency is invalid
+func MustNewMoney(amount Decimal, currency string) Money {
+	m, err := NewMoney(amount, currency)
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
+
+// ParseMoney parses an amount followed by a currency code, such as "12.34 EUR"
+func ParseMoney(s string) (Money, error) {
+	fields := strings.Fields(s)
+	if len(fields) != 2 {
+		return Money{}, fmt.Errorf("Invalid money `%s': expected amount and currency", s)
+	}
+	amount, err := NewFromString(fields[0])
+	if err != nil {
+		return Money{}, fmt.Errorf("Invalid money `%s': %v", s, err)
+	}
+	return NewMoney(amount, fields[1])
+}
+
+// MustParseMoney is like ParseMoney but panics if s is not valid
+func MustParseMoney(s string) Money {
+	m, err := ParseMoney(s)
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
+
+// Amount returns a copy of the amount of m
+func (m Money) Amount() Decimal {
+	return NewFromDecimal(m.amount)
+}
+
+// Currency returns the currency code of m
+func (m Money) Currency() string {
+	return m.currency
+}
+
+// SameCurrency returns true if m and o are in the same currency
+func (m Money) SameCurrency(o Money) bool {
+	return m.currency == o.currency
+}
+
+func (m Money) checkCurrency(o Money) error {
+	if !m.SameCurrency(o) {
+		return fmt.Errorf("Currency mismatch: %s and %s", m.currency, o.currency)
+	}
+	return nil
+}
+
+// Add returns the sum of m and o, it fails if they are in different currencies
+func (m Money) Add(o Money) (Money, error) {
+	if err := m.checkCurrency(o); err != nil {
+		return Money{}, err
+	}
+	return Money{amount: Add(m.amount, o.amount), currency: m.currency}, nil
+}
+
+// Sub returns o subtracted from m, it fails if they are in different currencies
+func (m Money) Sub(o Money) (Money, error) {
+	if err := m.checkCurrency(o); err != nil {
+		return Money{}, err
+	}
+	return Money{amount: Sub(m.amount, o.amount), currency: m.currency}, nil
+}
+
+// Mul returns m multiplied by the factor n
+func (m Money) Mul(n Decimal) Money {
+	return Money{amount: Mul(m.amount, n), currency: m.currency}
+}
+
+// Neg returns m with the opposite sign
+func (m Money) Neg() Money {
+	return m.Mul(NewFromInt(-1))
+}
+
+// Abs returns the absolute value of m
+func (m Money) Abs() Money {
+	return Money{amount: Abs(m.amount), currency: m.currency}
+}
+
+// CurrencyInfo returns the registry entry of the currency of m
+func (m Money) CurrencyInfo() Currency {
+	c, _ := LookupCurrency(m.currency)
+	return c
+}
+
+// MinorUnits returns the number of decimal places of the minor unit of the
+// currency of m, such as 2 for EUR (cents) or 0 for JPY. It is NoMinorUnits
+// for currencies without a minor unit.
+func (m Money) MinorUnits() int {
+	return m.CurrencyInfo().MinorUnits
+}
+
+// RoundToMinorUnits returns m rounded to the minor unit of its currency with
+// ToNearestEven, the rounding mode of Quantize.
+func (m Money) RoundToMinorUnits() Money {
+	return m.RoundToMinorUnitsMode(ToNearestEven)
+}
+
+// RoundToMinorUnitsMode returns m rounded to the minor unit of its currency with mode.
+// Amounts in currencies without a minor unit are not rounded.
+func (m Money) RoundToMinorUnitsMode(mode RoundingMode) Money {
+	units := m.MinorUnits()
+	if units == NoMinorUnits {
+		return Money{amount: NewFromDecimal(m.amount), currency: m.currency}
+	}
+	return Money{amount: QuantizeMode(m.amount, units, mode), currency: m.currency}
+}
+
+// Cmp compares m to o, it fails if they are in different currencies
+func (m Money) Cmp(o Money) (int, error) {
+	if err := m.checkCurrency(o); err != nil {
+		return 0, err
+	}
+	return m.amount.Cmp(o.amount), nil
+}
+
+// Equals returns true if m and o have the same currency and amount
+func (m Money) Equals(o Money) bool {
+	return m.SameCurrency(o) && m.amount.Equals(o.amount)
+}
+
+// LessThan returns true if m is less than o, it fails if they are in different currencies
+func (m Money) LessThan(o Money) (bool, error) {
+	c, err := m.Cmp(o)
+	return c < 0, err
+}
+
+// GreaterThan returns true if m is greater than o, it fails if they are in different currencies
+func (m Money) GreaterThan(o Money) (bool, error) {
+	c, err := m.Cmp(o)
+	return c > 0, err
+}
+
+// IsZero returns true if the amount of m is zero
+func (m Money) IsZero() bool {
+	return m.amount.Equals(Zero())
+}
+
+// IsNegative returns true if the amount of m is less than zero
+func (m Money) IsNegative() bool {
+	return m.amount.Cmp(Zero()) < 0
+}
+
+// IsPositive returns true if the amount of m is greater than zero
+func (m Money) IsPositive() bool {
+	return m.amount.Cmp(Zero()) > 0
+}
+
+// String returns the amount followed by the currency code, such as "12.34 EUR"
+func (m Money) String() string {
+	return m.amount.String() + " " + m.currency
+}
+
+// MarshalText implements the encoding.TextMarshaler interface for serialization
+func (m Money) MarshalText() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface for deserialization
+func (m *Money) UnmarshalText(buf []byte) error {
+	tmp, err := ParseMoney(string(buf))
+	if err != nil {
+		return err
+	}
+	*m = tmp
+	return nil
+}
+
+type jsonMoney struct {
+	Amount   Decimal `json:"amount"`
+	Currency string  `json:"currency"`
+}
+
+// MarshalJSON implements the json.Marshaler interface for serialization.
+// Money is encoded as an object like {"amount":12.34,"currency":"EUR"}.
+func (m Money) MarshalJSON() ([]byte, error) {
+	return json.Marshal(jsonMoney{Amount: m.amount, Currency: m.currency})
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface for deserialization.
+// It accepts the object encoding and strings like "12.34 EUR".
+func (m *Money) UnmarshalJSON(buf []byte) error {
+	buf = bytes.TrimSpace(buf)
+	if len(buf) > 0 && buf[0] == '"' {
+		return m.UnmarshalText(bytes.Trim(buf, `"`))
+	}
+	var tmp jsonMoney
+	if err := json.Unmarshal(buf, &tmp); err != nil {
+		return err
+	}
+	money, err := NewMoney(tmp.Amount, tmp.Currency)
+	if err != nil {
+		return err
+	}
+	*m = money
+	return nil
+}
+
+// Value implements the driver.Valuer interface for database serialization.
+// Money is stored in its text form, such as "12.34 EUR".
+func (m Money) Value() (driver.Value, error) {
+	return m.String(), nil
+}
+
+// Scan implements the sql.Scanner interface for database deserialization
+func (m *Money) Scan(value interface{}) error {
+	var s string
+	switch v := value.(type) {
+	case string:
+		s = v
+	case []byte:
+		s = string(v)
+	default:
+		return fmt.Errorf("Unable to create money from value type %T", value)
+	}
+	tmp, err := ParseMoney(s)
+	if err != nil {
+		return err
+	}
+	*m = tmp
+	return nil
+}
@@ -0,0 +1,159 @@
+package decimal
+
+import (
+	"database/sql"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewMoney(t *testing.T) {
+	m, err := NewMoney(MustNewFromString("12.34"), "eur")
+	require.NoError(t, err)
+	require.Equal(t, "EUR", m.Currency())
+	require.Equal(t, "12.34", m.Amount().String())
+	require.Equal(t, "12.34 EUR", m.String())
+
+	_, err = NewMoney(NewFromInt(1), "EURO")
+	require.EqualError(t, err, "Unknown currency `EURO'")
+
+	_, err = NewMoney(NewFromInt(1), "ABC")
+	require.EqualError(t, err, "Unknown currency `ABC'")
+
+	// modifying the amount afterwards does not change the money
+	d := NewFromInt(5)
+	m = MustNewMoney(d, "USD")
+	d.Add(NewFromInt(1))
+	require.Equal(t, "5 USD", m.String())
+}
+
+func TestParseMoney(t *testing.T) {
+	m, err := ParseMoney(" -0.50  chf ")
+	require.NoError(t, err)
+	require.Equal(t, "-0.50 CHF", m.String())
+
+	_, err = ParseMoney("12.34")
+	require.EqualError(t, err, "Invalid money `12.34': expected amount and currency")
+
+	_, err = ParseMoney("abc EUR")
+	require.EqualError(t, err, "Invalid money `abc EUR': Invalid decimal")
+}
+
+func TestMoneyArithmetic(t *testing.T) {
+	a := MustParseMoney("12.34 EUR")
+	b := MustParseMoney("0.66 EUR")
+	c := MustParseMoney("1.00 USD")
+
+	sum, err := a.Add(b)
+	require.NoError(t, err)
+	require.Equal(t, "13.00 EUR", sum.String())
+
+	diff, err := a.Sub(b)
+	require.NoError(t, err)
+	require.Equal(t, "11.68 EUR", diff.String())
+
+	_, err = a.Add(c)
+	require.EqualError(t, err, "Currency mismatch: EUR and USD")
+	_, err = a.Sub(c)
+	require.EqualError(t, err, "Currency mismatch: EUR and USD")
+
+	require.Equal(t, "37.02 EUR", a.Mul(NewFromInt(3)).String())
+	require.Equal(t, "-12.34 EUR", a.Neg().String())
+	require.Equal(t, "12.34 EUR", a.Neg().Abs().String())
+
+	// the operands are not modified
+	require.Equal(t, "12.34 EUR", a.String())
+	require.Equal(t, "0.66 EUR", b.String())
+}
+
+func TestMoneyRoundToMinorUnits(t *testing.T) {
+	testData := []struct {
+		input    string
+		expected string
+	}{
+		{input: "12.345 EUR", expected: "12.34 EUR"},
+		{input: "12.355 EUR", expected: "12.36 EUR"},
+		{input: "12.5 JPY", expected: "12 JPY"},
+		{input: "1.23456 KWD", expected: "1.235 KWD"},
+		{input: "1.23456 CLF", expected: "1.2346 CLF"},
+		{input: "7 EUR", expected: "7.00 EUR"},
+		{input: "1.23456 XAU", expected: "1.23456 XAU"},
+	}
+	for i, j := range testData {
+		require.Equal(t, j.expected, MustParseMoney(j.input).RoundToMinorUnits().String(), "At %d", i)
+	}
+	require.Equal(t, "12.35 EUR", MustParseMoney("12.345 EUR").RoundToMinorUnitsMode(ToNearestAway).String())
+	require.Equal(t, 0, MustParseMoney("1 JPY").MinorUnits())
+}
+
+func TestMoneyComparison(t *testing.T) {
+	a := MustParseMoney("10 EUR")
+	b := MustParseMoney("10.00 EUR")
+	c := MustParseMoney("11 EUR")
+	d := MustParseMoney("10 USD")
+
+	require.True(t, a.Equals(b))
+	require.False(t, a.Equals(c))
+	require.False(t, a.Equals(d))
+
+	cmp, err := a.Cmp(c)
+	require.NoError(t, err)
+	require.Equal(t, -1, cmp)
+
+	less, err := a.LessThan(c)
+	require.NoError(t, err)
+	require.True(t, less)
+
+	greater, err := c.GreaterThan(a)
+	require.NoError(t, err)
+	require.True(t, greater)
+
+	_, err = a.Cmp(d)
+	require.EqualError(t, err, "Currency mismatch: EUR and USD")
+	_, err = a.LessThan(d)
+	require.Error(t, err)
+
+	require.True(t, MustParseMoney("0.00 EUR").IsZero())
+	require.True(t, MustParseMoney("-1 EUR").IsNegative())
+	require.True(t, a.IsPositive())
+}
+
+func TestMoneyJSON(t *testing.T) {
+	buf, err := json.Marshal(MustParseMoney("12.34 EUR"))
+	require.NoError(t, err)
+	require.Equal(t, `{"amount":12.34,"currency":"EUR"}`, string(buf))
+
+	var m Money
+	require.NoError(t, json.Unmarshal([]byte(`{"amount":"0.5","currency":"usd"}`), &m))
+	require.Equal(t, "0.5 USD", m.String())
+
+	require.NoError(t, json.Unmarshal([]byte(`"7.25 GBP"`), &m))
+	require.Equal(t, "7.25 GBP", m.String())
+
+	require.EqualError(t, json.Unmarshal([]byte(`{"amount":1,"currency":"X"}`), &m), "Unknown currency `X'")
+
+	text, err := MustParseMoney("1 JPY").MarshalText()
+	require.NoError(t, err)
+	require.Equal(t, "1 JPY", string(text))
+}
+
+func TestMoneySQL(t *testing.T) {
+	db, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	defer db.Close()
+
+	rows, err := db.Query(`SELECT $1`, MustParseMoney("12.34 EUR"))
+	require.NoError(t, err)
+	defer rows.Close()
+	for rows.Next() {
+		var m Money
+		require.NoError(t, rows.Scan(&m))
+		require.Equal(t, "12.34 EUR", m.String())
+	}
+	require.NoError(t, rows.Err())
+
+	var m Money
+	require.EqualError(t, m.Scan(int64(1)), "Unable to create money from value type int64")
+	require.Error(t, m.Scan("12.34"))
+}
@@ -2,6 +2,7 @@ package decimal
 
 import (
 	gomath "math"
+	"math/big"
 	"strings"
 
 	"github.com/ericlagergren/decimal"
@@ -216,6 +217,94 @@ func Quantize(a Decimal, digits int) Decimal {
 	return d.Quantize(digits)
 }
 
+// QuantizeMode sets dec to the scale, digits, rounding dropped digits with mode.
+// Unlike Quantize it is not limited by the default precision of 16 digits.
+func (dec Decimal) QuantizeMode(digits int, mode RoundingMode) Decimal {
+	ctx := decimal.Context{Precision: decimal.UnlimitedPrecision, RoundingMode: mode.native()}
+	ctx.Quantize(dec.native(), digits)
+	if dec.native().IsFinite() && dec.native().Scale() != digits {
+		// rounding up a carry like 0.0999 to 0.10 drops a digit of scale,
+		// quantizing once more pads it again
+		ctx.Quantize(dec.native(), digits)
+	}
+	return Decimal{dec.native()}
+}
+
+// QuantizeMode sets a to the scale, digits, rounding dropped digits with mode.
+// a will not be modified.
+func QuantizeMode(a Decimal, digits int, mode RoundingMode) Decimal {
+	d := NewFromDecimal(a)
+	return d.QuantizeMode(digits, mode)
+}
+
+// DivMode divides n on the decimal instance and rounds the exact quotient to the
+// scale, digits, with mode. Unlike Div the result is rounded only once.
+// Dividing by zero results in NaN.
+func (dec Decimal) DivMode(n Decimal, digits int, mode RoundingMode) Decimal {
+	if !dec.native().IsFinite() || !n.native().IsFinite() || n.native().Sign() == 0 {
+		dec.native().SetNaN(false)
+		return Decimal{dec.native()}
+	}
+	q := new(big.Rat).Quo(dec.native().Rat(nil), n.native().Rat(nil))
+	r := newFromRat(q, digits, mode)
+	dec.native().Copy(r.native())
+	return Decimal{dec.native()}
+}
+
+// DivMode divides b from a, rounds the exact quotient to the scale, digits,
+// with mode and returns a new decimal instance.
+// a and b will not be modified
+func DivMode(a Decimal, b Decimal, digits int, mode RoundingMode) Decimal {
+	d := NewFromDecimal(a)
+	return d.DivMode(b, digits, mode)
+}
+
+// RoundToIncrement sets dec to the nearest multiple of increment, such as 0.05
+// or 25, rounding with mode. The sign of increment is ignored, the result has
+// the scale of increment. A zero or non-finite increment results in NaN.
+func (dec Decimal) RoundToIncrement(increment Decimal, mode RoundingMode) Decimal {
+	if !dec.native().IsFinite() || !increment.native().IsFinite() || increment.native().Sign() == 0 {
+		dec.native().SetNaN(false)
+		return Decimal{dec.native()}
+	}
+	inc := increment.native().Rat(nil)
+	inc.Abs(inc)
+	q := newFromRat(new(big.Rat).Quo(dec.native().Rat(nil), inc), 0, mode)
+	digits := increment.Scale()
+	if digits < 0 {
+		digits = 0
+	}
+	r := newFromRat(inc.Mul(inc, q.native().Rat(nil)), digits, ToZero)
+	dec.native().Copy(r.native())
+	return Decimal{dec.native()}
+}
+
+// RoundToIncrement rounds a to the nearest multiple of increment with mode
+// and returns a new decimal instance.
+// a and increment will not be modified
+func RoundToIncrement(a Decimal, increment Decimal, mode RoundingMode) Decimal {
+	d := NewFromDecimal(a)
+	return d.RoundToIncrement(increment, mode)
+}
+
+// IsMultipleOf returns true if dec is an integer multiple of increment.
+// Only zero is a multiple of zero.
+func (dec Decimal) IsMultipleOf(increment Decimal) bool {
+	if !dec.native().IsFinite() || !increment.native().IsFinite() {
+		return false
+	}
+	if increment.native().Sign() == 0 {
+		return dec.native().Sign() == 0
+	}
+	q := new(big.Rat).Quo(dec.native().Rat(nil), increment.native().Rat(nil))
+	return q.IsInt()
+}
+
+// IsMultipleOf returns true if a is an integer multiple of increment
+func IsMultipleOf(a Decimal, increment Decimal) bool {
+	return a.IsMultipleOf(increment)
+}
+
 // RoundToDigits rounds a to make it have as many digits if possible.
 func (dec Decimal) RoundToDigits(digits int) Decimal {
 	prec := dec.native().Precision()
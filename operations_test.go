@@ -361,3 +361,122 @@ func TestAlmostEquals(t *testing.T) {
 		data.VerifyIntegrity(t)
 	}
 }
+
+func TestQuantizeMode(t *testing.T) {
+	testData := []struct {
+		input    string
+		digits   int
+		mode     decimal.RoundingMode
+		expected string
+	}{
+		{input: "2.345", digits: 2, mode: decimal.ToNearestEven, expected: "2.34"},
+		{input: "2.345", digits: 2, mode: decimal.ToNearestAway, expected: "2.35"},
+		{input: "-2.345", digits: 2, mode: decimal.ToNearestAway, expected: "-2.35"},
+		{input: "2.341", digits: 2, mode: decimal.AwayFromZero, expected: "2.35"},
+		{input: "2.349", digits: 2, mode: decimal.ToZero, expected: "2.34"},
+		{input: "-2.341", digits: 2, mode: decimal.ToNegativeInf, expected: "-2.35"},
+		{input: "-2.349", digits: 2, mode: decimal.ToPositiveInf, expected: "-2.34"},
+		{input: "1.5", digits: 3, mode: decimal.ToZero, expected: "1.500"},
+		{input: "0.09999999999999961", digits: 10, mode: decimal.ToNearestAway, expected: "0.1000000000"},
+		{input: "123456789012345678.125", digits: 2, mode: decimal.ToNearestAway, expected: "123456789012345678.13"},
+	}
+	for i, j := range testData {
+		data := setup(j.input)
+		output := decimal.QuantizeMode(data.Decimals[0], j.digits, j.mode).String()
+		require.Equal(t, j.expected, output, "At %d: %s ≠ %s", i, j.expected, output)
+		data.VerifyIntegrity(t)
+	}
+}
+
+func TestDivMode(t *testing.T) {
+	testData := []struct {
+		a        string
+		b        string
+		digits   int
+		mode     decimal.RoundingMode
+		expected string
+	}{
+		{a: "1", b: "3", digits: 4, mode: decimal.ToNearestEven, expected: "0.3333"},
+		{a: "2", b: "3", digits: 4, mode: decimal.ToNearestEven, expected: "0.6667"},
+		{a: "2", b: "3", digits: 4, mode: decimal.ToZero, expected: "0.6666"},
+		{a: "-2", b: "3", digits: 4, mode: decimal.ToNegativeInf, expected: "-0.6667"},
+		{a: "-2", b: "3", digits: 4, mode: decimal.ToPositiveInf, expected: "-0.6666"},
+		{a: "1", b: "8", digits: 2, mode: decimal.ToNearestEven, expected: "0.12"},
+		{a: "3", b: "8", digits: 2, mode: decimal.ToNearestEven, expected: "0.38"},
+		{a: "1", b: "8", digits: 2, mode: decimal.ToNearestAway, expected: "0.13"},
+		{a: "-1", b: "8", digits: 2, mode: decimal.ToNearestAway, expected: "-0.13"},
+		{a: "1", b: "8", digits: 2, mode: decimal.AwayFromZero, expected: "0.13"},
+		{a: "10", b: "4", digits: 0, mode: decimal.ToNearestEven, expected: "2"},
+		{a: "10", b: "4", digits: 3, mode: decimal.ToNearestEven, expected: "2.500"},
+		{a: "12345678901234567890", b: "7", digits: 2, mode: decimal.ToNearestEven, expected: "1763668414462081127.14"},
+	}
+	for i, j := range testData {
+		data := setup(j.a, j.b)
+		output := decimal.DivMode(data.Decimals[0], data.Decimals[1], j.digits, j.mode).String()
+		require.Equal(t, j.expected, output, "At %d: %s ≠ %s", i, j.expected, output)
+		data.VerifyIntegrity(t)
+	}
+	require.True(t, decimal.DivMode(decimal.NewFromInt(1), decimal.Zero(), 2, decimal.ToNearestEven).IsNaN())
+}
+
+func TestRoundToIncrement(t *testing.T) {
+	testData := []struct {
+		a         string
+		increment string
+		mode      decimal.RoundingMode
+		expected  string
+	}{
+		{a: "1.02", increment: "0.05", mode: decimal.ToNearestEven, expected: "1.00"},
+		{a: "1.03", increment: "0.05", mode: decimal.ToNearestEven, expected: "1.05"},
+		{a: "1.025", increment: "0.05", mode: decimal.ToNearestEven, expected: "1.00"},
+		{a: "1.075", increment: "0.05", mode: decimal.ToNearestEven, expected: "1.10"},
+		{a: "1.025", increment: "0.05", mode: decimal.ToNearestAway, expected: "1.05"},
+		{a: "-1.025", increment: "0.05", mode: decimal.ToNearestAway, expected: "-1.05"},
+		{a: "-1.03", increment: "0.05", mode: decimal.ToZero, expected: "-1.00"},
+		{a: "-1.01", increment: "0.05", mode: decimal.ToNegativeInf, expected: "-1.05"},
+		{a: "-1.04", increment: "0.05", mode: decimal.ToPositiveInf, expected: "-1.00"},
+		{a: "1.01", increment: "0.05", mode: decimal.AwayFromZero, expected: "1.05"},
+		{a: "7.14", increment: "0.10", mode: decimal.ToNearestEven, expected: "7.10"},
+		{a: "0.37", increment: "0.25", mode: decimal.ToNearestEven, expected: "0.25"},
+		{a: "0.38", increment: "0.25", mode: decimal.ToNearestEven, expected: "0.50"},
+		{a: "0.375", increment: "0.25", mode: decimal.ToNearestEven, expected: "0.50"},
+		{a: "112.5", increment: "25", mode: decimal.ToNearestEven, expected: "100"},
+		{a: "137.5", increment: "25", mode: decimal.ToNearestEven, expected: "150"},
+		{a: "1249", increment: "500", mode: decimal.ToNearestEven, expected: "1000"},
+		{a: "1250", increment: "500", mode: decimal.ToNearestAway, expected: "1500"},
+		{a: "1", increment: "500", mode: decimal.ToPositiveInf, expected: "500"},
+		{a: "1.03", increment: "-0.05", mode: decimal.ToNearestEven, expected: "1.05"},
+		{a: "12345678901234567.891", increment: "0.05", mode: decimal.ToNearestEven, expected: "12345678901234567.90"},
+	}
+	for i, j := range testData {
+		data := setup(j.a, j.increment)
+		output := decimal.RoundToIncrement(data.Decimals[0], data.Decimals[1], j.mode).String()
+		require.Equal(t, j.expected, output, "At %d: %s ≠ %s", i, j.expected, output)
+		data.VerifyIntegrity(t)
+	}
+	require.True(t, decimal.RoundToIncrement(decimal.NewFromInt(1), decimal.Zero(), decimal.ToNearestEven).IsNaN())
+}
+
+func TestIsMultipleOf(t *testing.T) {
+	testData := []struct {
+		a         string
+		increment string
+		expected  bool
+	}{
+		{a: "1.05", increment: "0.05", expected: true},
+		{a: "1.050000", increment: "0.05", expected: true},
+		{a: "-1.05", increment: "0.05", expected: true},
+		{a: "1.06", increment: "0.05", expected: false},
+		{a: "0.75", increment: "0.25", expected: true},
+		{a: "1500", increment: "500", expected: true},
+		{a: "1250", increment: "500", expected: false},
+		{a: "0", increment: "0.05", expected: true},
+		{a: "0", increment: "0", expected: true},
+		{a: "1", increment: "0", expected: false},
+	}
+	for i, j := range testData {
+		data := setup(j.a, j.increment)
+		require.Equal(t, j.expected, decimal.IsMultipleOf(data.Decimals[0], data.Decimals[1]), "At %d", i)
+		data.VerifyIntegrity(t)
+	}
+}
@@ -0,0 +1,223 @@
+package decimal
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/ericlagergren/decimal"
+)
+
+// ParseOptions restricts the syntax and size of the decimals a Parser accepts.
+// The zero value accepts plain decimals such as "-12.50" and ".5" of any size.
+type ParseOptions struct {
+	// AllowExponent accepts exponent notation such as "1.5E+3"
+	AllowExponent bool
+	// AllowSpecial accepts "Inf", "Infinity" and their negatives, NaN is
+	// never accepted
+	AllowSpecial bool
+	// AllowLeadingPlus accepts a leading plus sign
+	AllowLeadingPlus bool
+	// AllowTrailingPoint accepts a decimal point without fractional digits, "1."
+	AllowTrailingPoint bool
+	// MaxLength is the maximum length of the input in bytes, it is checked
+	// before any other work. 0 means no limit.
+	MaxLength int
+	// MaxDigits is the maximum number of digits without leading zeros. 0
+	// means no limit.
+	MaxDigits int
+	// MaxScale is the maximum number of digits after the decimal point of the
+	// parsed value, "1.5E-3" has a scale of 4. 0 means no limit.
+	MaxScale int
+	// MaxExponent is the maximum absolute exponent of the value in scientific
+	// notation, 1.5E+7 and 0.00000015 have exponents 7 and -7. 0 means no limit.
+	MaxExponent int
+}
+
+// Parser parses decimals with options. Parser is immutable and safe for
+// concurrent use.
+type Parser struct {
+	options ParseOptions
+}
+
+// NewParser returns a parser with the options
+func NewParser(options ParseOptions) Parser {
+	return Parser{options: options}
+}
+
+// Options returns the options of p
+func (p Parser) Options() ParseOptions {
+	return p.options
+}
+
+const (
+	// maxExponentDigits bounds the digits of an exponent without MaxExponent
+	// to keep it in the range of int
+	maxExponentDigits = 9
+	// maxMantissaDigits is the number of digits that always fit in an int64
+	maxMantissaDigits = 18
+)
+
+var errInvalid = errors.New("Invalid decimal")
+
+// Parse parses s with the options of p. Malformed input fails with "Invalid
+// decimal" like NewFromString, input the options reject with the reason.
+func (p Parser) Parse(s string) (Decimal, error) {
+	return p.parse([]byte(s))
+}
+
+// ParseBytes is like Parse but parses buf without converting it to a string
+func (p Parser) ParseBytes(buf []byte) (Decimal, error) {
+	return p.parse(buf)
+}
+
+// ParseBytes parses buf with the DefaultParser
+func ParseBytes(buf []byte) (Decimal, error) {
+	return DefaultParser().parse(buf)
+}
+
+func (p Parser) parse(s []byte) (Decimal, error) {
+	o := p.options
+	if o.MaxLength > 0 && len(s) > o.MaxLength {
+		return Decimal{}, fmt.Errorf("Invalid decimal: length %d exceeds %d", len(s), o.MaxLength)
+	}
+	reject := func(format string, args ...interface{}) (Decimal, error) {
+		return Decimal{}, fmt.Errorf("Invalid decimal `%s': %s", string(s), fmt.Sprintf(format, args...))
+	}
+
+	i := 0
+	negative := false
+	if i < len(s) && (s[i] == '+' || s[i] == '-') {
+		if s[i] == '+' && !o.AllowLeadingPlus {
+			return reject("leading '+' not allowed")
+		}
+		negative = s[i] == '-'
+		i++
+	}
+	if bytes.EqualFold(s[i:], []byte("inf")) || bytes.EqualFold(s[i:], []byte("infinity")) {
+		if !o.AllowSpecial {
+			return reject("special value not allowed")
+		}
+		return Decimal{decimal.New(0, 0).SetInf(negative)}, nil
+	}
+
+	digits, significant, fraction := 0, 0, 0
+	var mantissa int64
+	point := false
+	for ; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c >= '0' && c <= '9':
+			digits++
+			if c != '0' || significant > 0 {
+				significant++
+			}
+			if point {
+				fraction++
+			}
+			if significant <= maxMantissaDigits {
+				mantissa = mantissa*10 + int64(c-'0')
+			}
+			continue
+		case c == '.' && !point:
+			point = true
+			continue
+		}
+		break
+	}
+	if digits == 0 {
+		return Decimal{}, errInvalid
+	}
+	if point && fraction == 0 && !o.AllowTrailingPoint {
+		return reject("trailing decimal point not allowed")
+	}
+
+	exp := 0
+	if i < len(s) && (s[i] == 'e' || s[i] == 'E') {
+		if !o.AllowExponent {
+			return reject("exponent notation not allowed")
+		}
+		i++
+		negativeExp := false
+		if i < len(s) && (s[i] == '+' || s[i] == '-') {
+			negativeExp = s[i] == '-'
+			i++
+		}
+		start := i
+		for ; i < len(s) && s[i] >= '0' && s[i] <= '9'; i++ {
+			if i-start == maxExponentDigits {
+				return reject("exponent out of range")
+			}
+			exp = exp*10 + int(s[i]-'0')
+		}
+		if i == start {
+			return Decimal{}, errInvalid
+		}
+		if negativeExp {
+			exp = -exp
+		}
+	}
+	if i != len(s) {
+		return Decimal{}, errInvalid
+	}
+
+	if significant == 0 {
+		significant = 1
+	}
+	if o.MaxDigits > 0 && significant > o.MaxDigits {
+		return reject("more than %d digits", o.MaxDigits)
+	}
+	if scale := fraction - exp; o.MaxScale > 0 && scale > o.MaxScale {
+		return reject("scale %d exceeds %d", scale, o.MaxScale)
+	}
+	adjusted := exp - fraction + significant - 1
+	if adjusted < 0 {
+		adjusted = -adjusted
+	}
+	if o.MaxExponent > 0 && adjusted > o.MaxExponent {
+		return reject("exponent exceeds %d", o.MaxExponent)
+	}
+
+	d := decimal.New(0, 0)
+	if significant <= maxMantissaDigits && !(negative && mantissa == 0) {
+		if negative {
+			mantissa = -mantissa
+		}
+		return Decimal{d.SetMantScale(mantissa, fraction-exp)}, nil
+	}
+	if _, ok := d.SetString(string(s)); !ok {
+		return Decimal{}, errInvalid
+	}
+	return Decimal{d}, nil
+}
+
+// defaultParser is used by UnmarshalText, UnmarshalJSON and Scan. It accepts
+// the syntax of NewFromString but limits the size of the input.
+var defaultParser = struct {
+	mu sync.RWMutex
+	p  Parser
+}{p: NewParser(ParseOptions{
+	AllowExponent:      true,
+	AllowSpecial:       true,
+	AllowLeadingPlus:   true,
+	AllowTrailingPoint: true,
+	MaxLength:          1024,
+	MaxExponent:        6144,
+})}
+
+// DefaultParser returns the parser used by UnmarshalText, UnmarshalJSON and
+// Scan. Initially it accepts the syntax of NewFromString for inputs of up to
+// 1024 bytes with exponents of up to 6144, the limit of decimal128.
+func DefaultParser() Parser {
+	defaultParser.mu.RLock()
+	defer defaultParser.mu.RUnlock()
+	return defaultParser.p
+}
+
+// SetDefaultParser sets the parser used by UnmarshalText, UnmarshalJSON and Scan
+func SetDefaultParser(p Parser) {
+	defaultParser.mu.Lock()
+	defer defaultParser.mu.Unlock()
+	defaultParser.p = p
+}
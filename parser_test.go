@@ -0,0 +1,86 @@
+package decimal_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/talon-one/decimal"
+)
+
+func TestParser(t *testing.T) {
+	lenient := decimal.ParseOptions{AllowExponent: true, AllowSpecial: true, AllowLeadingPlus: true, AllowTrailingPoint: true}
+	testData := []struct {
+		options  decimal.ParseOptions
+		input    string
+		expected string
+		err      string
+	}{
+		{input: "-12.50", expected: "-12.50"},
+		{input: ".5", expected: "0.5"},
+		{input: "007", expected: "7"},
+		{input: "1.5E+3", err: "Invalid decimal `1.5E+3': exponent notation not allowed"},
+		{input: "Inf", err: "Invalid decimal `Inf': special value not allowed"},
+		{input: "+1", err: "Invalid decimal `+1': leading '+' not allowed"},
+		{input: "1.", err: "Invalid decimal `1.': trailing decimal point not allowed"},
+		{input: "", err: "Invalid decimal"},
+		{input: "-", err: "Invalid decimal"},
+		{input: ".", err: "Invalid decimal"},
+		{input: "1.2.3", err: "Invalid decimal"},
+		{input: "1,5", err: "Invalid decimal"},
+		{input: " 1", err: "Invalid decimal"},
+		{input: "NaN", err: "Invalid decimal"},
+		{options: lenient, input: "NaN", err: "Invalid decimal"},
+		{options: lenient, input: "1.5e3", expected: "1500"},
+		{options: lenient, input: "1E", err: "Invalid decimal"},
+		{options: lenient, input: "1E+", err: "Invalid decimal"},
+		{options: lenient, input: "-Infinity", expected: "-Infinity"},
+		{options: lenient, input: "+inf", expected: "Infinity"},
+		{options: lenient, input: "+1.", expected: "1"},
+		{options: lenient, input: "1e9999999999", err: "Invalid decimal `1e9999999999': exponent out of range"},
+		{options: decimal.ParseOptions{MaxLength: 5}, input: "123456", err: "Invalid decimal: length 6 exceeds 5"},
+		{options: decimal.ParseOptions{MaxLength: 5}, input: "12345", expected: "12345"},
+		{options: decimal.ParseOptions{MaxDigits: 3}, input: "1234", err: "Invalid decimal `1234': more than 3 digits"},
+		{options: decimal.ParseOptions{MaxDigits: 3}, input: "000.123", expected: "0.123"},
+		{options: decimal.ParseOptions{MaxDigits: 3}, input: "0.0000", expected: "0"},
+		{options: decimal.ParseOptions{MaxScale: 2}, input: "1.005", err: "Invalid decimal `1.005': scale 3 exceeds 2"},
+		{options: decimal.ParseOptions{MaxScale: 2, AllowExponent: true}, input: "1.5E-3", err: "Invalid decimal `1.5E-3': scale 4 exceeds 2"},
+		{options: decimal.ParseOptions{MaxScale: 2, AllowExponent: true}, input: "15E-1", expected: "1.5"},
+		{options: decimal.ParseOptions{MaxExponent: 6, AllowExponent: true}, input: "1.5E+7", err: "Invalid decimal `1.5E+7': exponent exceeds 6"},
+		{options: decimal.ParseOptions{MaxExponent: 6, AllowExponent: true}, input: "99999999", err: "Invalid decimal `99999999': exponent exceeds 6"},
+		{options: decimal.ParseOptions{MaxExponent: 6, AllowExponent: true}, input: "999999.9", expected: "999999.9"},
+		{options: decimal.ParseOptions{MaxExponent: 6}, input: "0.00000015", err: "Invalid decimal `0.00000015': exponent exceeds 6"},
+		{options: decimal.ParseOptions{MaxExponent: 6, AllowExponent: true}, input: "150000E-11", expected: "0.00000150000"},
+	}
+	for i, j := range testData {
+		d, err := decimal.NewParser(j.options).Parse(j.input)
+		if j.err != "" {
+			require.EqualError(t, err, j.err, "At %d", i)
+			continue
+		}
+		require.NoError(t, err, "At %d", i)
+		require.Equal(t, j.expected, d.String(), "At %d", i)
+	}
+}
+
+func TestDefaultParser(t *testing.T) {
+	defer decimal.SetDefaultParser(decimal.DefaultParser())
+
+	var d decimal.Decimal
+	require.NoError(t, json.Unmarshal([]byte(`"+1.5e3"`), &d))
+	require.Equal(t, "1500", d.String())
+	require.EqualError(t, json.Unmarshal([]byte(`"1e999999"`), &d), "Invalid decimal `1e999999': exponent exceeds 6144")
+	require.EqualError(t, d.UnmarshalText([]byte(strings.Repeat("1", 2000))), "Invalid decimal: length 2000 exceeds 1024")
+	require.EqualError(t, d.Scan("1e7000"), "Invalid decimal `1e7000': exponent exceeds 6144")
+
+	decimal.SetDefaultParser(decimal.NewParser(decimal.ParseOptions{MaxScale: 2}))
+	require.False(t, decimal.DefaultParser().Options().AllowExponent)
+	require.NoError(t, d.UnmarshalJSON([]byte(`12.34`)))
+	require.Equal(t, "12.34", d.String())
+	require.EqualError(t, d.UnmarshalJSON([]byte(`12.345`)), "Invalid decimal `12.345': scale 3 exceeds 2")
+	require.EqualError(t, d.UnmarshalText([]byte(`1E3`)), "Invalid decimal `1E3': exponent notation not allowed")
+	require.EqualError(t, d.Scan([]byte("0.001")), "Invalid decimal `0.001': scale 3 exceeds 2")
+	require.NoError(t, d.Scan(int64(7)))
+	require.Equal(t, "7", d.String())
+}
@@ -0,0 +1,209 @@
+package decimal
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// PercentRatioDigits is the number of decimal places of the ratio kept by
+// Percent operations that have to divide, 10 places of the ratio are 8 places
+// of percent. Those operations round with ToNearestEven.
+const PercentRatioDigits = 10
+
+// Percent is a ratio expressed in percent, 15% is the ratio 0.15 or 1500 basis points.
+// The zero value is 0%.
+type Percent struct {
+	ratio Decimal
+}
+
+// NewPercent returns p percent, NewPercent(NewFromInt(15)) is 15%.
+func NewPercent(p Decimal) Percent {
+	return Percent{shift(p, 2)}
+}
+
+// NewPercentFromRatio returns the ratio r in percent, NewPercentFromRatio(0.15) is 15%.
+func NewPercentFromRatio(r Decimal) Percent {
+	return Percent{NewFromDecimal(r)}
+}
+
+// NewPercentFromBasisPoints returns bp basis points in percent, 1500bp is 15%.
+func NewPercentFromBasisPoints(bp Decimal) Percent {
+	return Percent{shift(bp, 4)}
+}
+
+// shift moves the decimal point of d by places to the left without any rounding
+func shift(d Decimal, places int) Decimal {
+	cpy := NewFromDecimal(d)
+	if cpy.native().IsFinite() {
+		cpy.native().SetScale(cpy.Scale() + places)
+	}
+	return cpy
+}
+
+// ParsePercent parses a percentage such as "15%", "15.5 %" or "-2.5%" or an
+// amount of basis points such as "1500bp" or "1500 bps". A unit is required.
+func ParsePercent(s string) (Percent, error) {
+	str := strings.TrimSpace(s)
+	places := 0
+	switch lower := strings.ToLower(str); {
+	case strings.HasSuffix(lower, "%"):
+		str, places = str[:len(str)-1], 2
+	case strings.HasSuffix(lower, "bps"):
+		str, places = str[:len(str)-3], 4
+	case strings.HasSuffix(lower, "bp"):
+		str, places = str[:len(str)-2], 4
+	default:
+		return Percent{}, fmt.Errorf("Invalid percent `%s': missing %% or bp unit", s)
+	}
+	d, err := NewFromString(strings.TrimSpace(str))
+	if err != nil {
+		return Percent{}, fmt.Errorf("Invalid percent `%s': %v", s, err)
+	}
+	if !d.native().IsFinite() {
+		return Percent{}, fmt.Errorf("Invalid percent `%s': not finite", s)
+	}
+	return Percent{shift(d, places)}, nil
+}
+
+// MustParsePercent is like ParsePercent but panics if s is not a valid percentage
+func MustParsePercent(s string) Percent {
+	p, err := ParsePercent(s)
+	if err != nil {
+		panic(err)
+	}
+	return p
+}
+
+// Ratio returns p as a ratio, 15% is 0.15
+func (p Percent) Ratio() Decimal {
+	return NewFromDecimal(p.ratio)
+}
+
+// Percent returns p in percent, 15% is 15
+func (p Percent) Percent() Decimal {
+	return shift(p.ratio, -2)
+}
+
+// BasisPoints returns p in basis points, 15% is 1500
+func (p Percent) BasisPoints() Decimal {
+	return shift(p.ratio, -4)
+}
+
+// Equals returns true if p and o are the same percentage
+func (p Percent) Equals(o Percent) bool {
+	return p.ratio.Equals(o.ratio)
+}
+
+// Of returns p of x, 15% of 200 is 30. The result is exact, its scale is the
+// scale of x plus the scale of the ratio. x will not be modified.
+func (p Percent) Of(x Decimal) Decimal {
+	return p.apply(x, 0, 1)
+}
+
+// ApplyDiscount returns x reduced by p, 200 with a 15% discount is 170.
+// The result is exact, its scale is the scale of x plus the scale of the ratio,
+// or of x if the ratio has a negative scale. x will not be modified.
+func (p Percent) ApplyDiscount(x Decimal) Decimal {
+	return p.apply(x, 1, -1)
+}
+
+// ApplyIncrease returns x increased by p, 200 with a 15% increase is 230.
+// The result is exact, its scale is the scale of x plus the scale of the ratio,
+// or of x if the ratio has a negative scale. x will not be modified.
+func (p Percent) ApplyIncrease(x Decimal) Decimal {
+	return p.apply(x, 1, 1)
+}
+
+// apply returns x * (base + sign * ratio) without rounding, non-finite
+// operands follow the rules of Mul, Add and Sub
+func (p Percent) apply(x Decimal, base, sign int64) Decimal {
+	if !x.native().IsFinite() || !p.ratio.native().IsFinite() {
+		of := Mul(x, p.ratio)
+		switch {
+		case base == 0:
+			return of
+		case sign < 0:
+			return Sub(x, of)
+		}
+		return Add(x, of)
+	}
+	digits := p.ratio.Scale()
+	if base != 0 && digits < 0 {
+		digits = 0
+	}
+	factor := p.ratio.native().Rat(nil)
+	factor.Mul(factor, big.NewRat(sign, 1)).Add(factor, big.NewRat(base, 1))
+	return newFromRat(factor.Mul(factor, x.native().Rat(nil)), x.Scale()+digits, ToZero)
+}
+
+// MarginToMarkup converts p from a margin, the profit as part of the price,
+// into a markup, the profit as part of the cost: a 20% margin is a 25% markup.
+// The ratio is rounded to PercentRatioDigits places with ToNearestEven.
+// It fails for a margin of 100%.
+func (p Percent) MarginToMarkup() (Percent, error) {
+	cost := Sub(NewFromInt(1), p.ratio)
+	if cost.Equals(Zero()) {
+		return Percent{}, errors.New("A margin of 100% has no markup")
+	}
+	return Percent{DivMode(p.ratio, cost, PercentRatioDigits, ToNearestEven)}, nil
+}
+
+// MarkupToMargin converts p from a markup, the profit as part of the cost,
+// into a margin, the profit as part of the price: a 25% markup is a 20% margin.
+// The ratio is rounded to PercentRatioDigits places with ToNearestEven.
+// It fails for a markup of -100%.
+func (p Percent) MarkupToMargin() (Percent, error) {
+	price := Add(NewFromInt(1), p.ratio)
+	if price.Equals(Zero()) {
+		return Percent{}, errors.New("A markup of -100% has no margin")
+	}
+	return Percent{DivMode(p.ratio, price, PercentRatioDigits, ToNearestEven)}, nil
+}
+
+// PercentChange returns the relative change from a to b, from 80 to 100 is 25%.
+// The ratio is rounded to PercentRatioDigits places with ToNearestEven.
+// It fails if a is zero.
+func PercentChange(a, b Decimal) (Percent, error) {
+	if a.Equals(Zero()) {
+		return Percent{}, errors.New("Percent change from zero is undefined")
+	}
+	return Percent{DivMode(Sub(b, a), Abs(a), PercentRatioDigits, ToNearestEven)}, nil
+}
+
+// String returns p in percent with a % sign, such as "15.5%"
+func (p Percent) String() string {
+	return p.Percent().String() + "%"
+}
+
+// MarshalText implements the encoding.TextMarshaler interface for serialization
+func (p Percent) MarshalText() ([]byte, error) {
+	return []byte(p.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface for deserialization
+func (p *Percent) UnmarshalText(buf []byte) error {
+	tmp, err := ParsePercent(string(buf))
+	if err != nil {
+		return err
+	}
+	*p = tmp
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface for serialization.
+// The percentage is encoded as a JSON string with its unit, such as "15%".
+func (p Percent) MarshalJSON() ([]byte, error) {
+	buf, err := p.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	return []byte(`"` + string(buf) + `"`), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface for deserialization
+func (p *Percent) UnmarshalJSON(buf []byte) error {
+	return p.UnmarshalText(bytes.Trim(bytes.TrimSpace(buf), `"`))
+}
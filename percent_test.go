@@ -0,0 +1,158 @@
+package decimal_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/talon-one/decimal"
+)
+
+func TestParsePercent(t *testing.T) {
+	testData := []struct {
+		input string
+		ratio string
+		str   string
+		err   string
+	}{
+		{input: "15%", ratio: "0.15", str: "15%"},
+		{input: "15.5 %", ratio: "0.155", str: "15.5%"},
+		{input: " -2.5%", ratio: "-0.025", str: "-2.5%"},
+		{input: "1500bp", ratio: "0.1500", str: "15.00%"},
+		{input: "25 BPS", ratio: "0.0025", str: "0.25%"},
+		{input: "100%", ratio: "1.00", str: "100%"},
+		{input: "0.15", err: "Invalid percent `0.15': missing % or bp unit"},
+		{input: "abc%", err: "Invalid percent `abc%': Invalid decimal"},
+		{input: "Inf%", err: "Invalid percent `Inf%': not finite"},
+		{input: "%", err: "Invalid percent `%': Invalid decimal"},
+	}
+	for i, j := range testData {
+		p, err := decimal.ParsePercent(j.input)
+		if j.err != "" {
+			require.EqualError(t, err, j.err, "At %d", i)
+			continue
+		}
+		require.NoError(t, err, "At %d", i)
+		require.Equal(t, j.ratio, p.Ratio().String(), "At %d", i)
+		require.Equal(t, j.str, p.String(), "At %d", i)
+	}
+}
+
+func TestNewPercent(t *testing.T) {
+	data := setup("15", "0.15", "1500")
+	p := decimal.NewPercent(data.Decimals[0])
+	require.True(t, p.Equals(decimal.NewPercentFromRatio(data.Decimals[1])))
+	require.True(t, p.Equals(decimal.NewPercentFromBasisPoints(data.Decimals[2])))
+	require.Equal(t, "15", p.Percent().String())
+	require.Equal(t, "1500", p.BasisPoints().String())
+	require.Equal(t, "0.15", p.Ratio().String())
+	data.VerifyIntegrity(t)
+
+	require.Equal(t, "0%", decimal.Percent{}.String())
+}
+
+func TestPercentApply(t *testing.T) {
+	p := decimal.MustParsePercent("15%")
+	data := setup("200", "19.99")
+	require.Equal(t, "30.00", p.Of(data.Decimals[0]).String())
+	require.Equal(t, "170.00", p.ApplyDiscount(data.Decimals[0]).String())
+	require.Equal(t, "230.00", p.ApplyIncrease(data.Decimals[0]).String())
+	require.Equal(t, "2.9985", p.Of(data.Decimals[1]).String())
+	require.Equal(t, "16.9915", p.ApplyDiscount(data.Decimals[1]).String())
+	require.Equal(t, "22.9885", p.ApplyIncrease(data.Decimals[1]).String())
+	data.VerifyIntegrity(t)
+}
+
+func TestPercentApplyExact(t *testing.T) {
+	testData := []struct {
+		percent  string
+		value    string
+		of       string
+		discount string
+		increase string
+	}{
+		{percent: "15.5%", value: "12345678901234567.89", of: "1913580229691358.02295", discount: "10432098671543209.86705", increase: "14259259130925925.91295"},
+		{percent: "-2.5%", value: "40", of: "-1.000", discount: "41.000", increase: "39.000"},
+		{percent: "1E+3%", value: "2.5", of: "25", discount: "-22.5", increase: "27.5"},
+		{percent: "100%", value: "123456789012345678901234567890", of: "123456789012345678901234567890.00", discount: "0", increase: "246913578024691357802469135780.00"},
+	}
+	for i, j := range testData {
+		p := decimal.MustParsePercent(j.percent)
+		data := setup(j.value)
+		require.Equal(t, j.of, p.Of(data.Decimals[0]).String(), "At %d", i)
+		require.Equal(t, j.discount, p.ApplyDiscount(data.Decimals[0]).String(), "At %d", i)
+		require.Equal(t, j.increase, p.ApplyIncrease(data.Decimals[0]).String(), "At %d", i)
+		data.VerifyIntegrity(t)
+	}
+
+	inf := decimal.MustNewFromString("Infinity")
+	require.Equal(t, "Infinity", decimal.MustParsePercent("15%").Of(inf).String())
+	require.Equal(t, "Infinity", decimal.MustParsePercent("15%").ApplyIncrease(inf).String())
+}
+
+func TestMarginAndMarkup(t *testing.T) {
+	markup, err := decimal.MustParsePercent("20%").MarginToMarkup()
+	require.NoError(t, err)
+	require.Equal(t, "25.00000000%", markup.String())
+
+	margin, err := decimal.MustParsePercent("25%").MarkupToMargin()
+	require.NoError(t, err)
+	require.Equal(t, "20.00000000%", margin.String())
+
+	markup, err = decimal.MustParsePercent("10%").MarginToMarkup()
+	require.NoError(t, err)
+	require.Equal(t, "11.11111111%", markup.String())
+
+	margin, err = decimal.MustParsePercent("50%").MarkupToMargin()
+	require.NoError(t, err)
+	require.Equal(t, "33.33333333%", margin.String())
+
+	_, err = decimal.MustParsePercent("100%").MarginToMarkup()
+	require.EqualError(t, err, "A margin of 100% has no markup")
+
+	_, err = decimal.MustParsePercent("-100%").MarkupToMargin()
+	require.EqualError(t, err, "A markup of -100% has no margin")
+}
+
+func TestPercentChange(t *testing.T) {
+	testData := []struct {
+		a        string
+		b        string
+		expected string
+	}{
+		{a: "80", b: "100", expected: "25.00000000%"},
+		{a: "100", b: "80", expected: "-20.00000000%"},
+		{a: "3", b: "4", expected: "33.33333333%"},
+		{a: "-100", b: "-80", expected: "20.00000000%"},
+		{a: "5", b: "5", expected: "0%"},
+	}
+	for i, j := range testData {
+		data := setup(j.a, j.b)
+		p, err := decimal.PercentChange(data.Decimals[0], data.Decimals[1])
+		require.NoError(t, err, "At %d", i)
+		require.Equal(t, j.expected, p.String(), "At %d", i)
+		data.VerifyIntegrity(t)
+	}
+
+	_, err := decimal.PercentChange(decimal.Zero(), decimal.NewFromInt(1))
+	require.EqualError(t, err, "Percent change from zero is undefined")
+}
+
+func TestPercentJSON(t *testing.T) {
+	type rule struct {
+		Discount decimal.Percent
+	}
+	buf, err := json.Marshal(rule{Discount: decimal.MustParsePercent("15.5%")})
+	require.NoError(t, err)
+	require.Equal(t, `{"Discount":"15.5%"}`, string(buf))
+
+	var r rule
+	require.NoError(t, json.Unmarshal([]byte(`{"Discount":"1500bp"}`), &r))
+	require.Equal(t, "0.1500", r.Discount.Ratio().String())
+
+	require.EqualError(t, json.Unmarshal([]byte(`{"Discount":"15"}`), &r), "Invalid percent `15': missing % or bp unit")
+
+	text, err := decimal.MustParsePercent("2.5%").MarshalText()
+	require.NoError(t, err)
+	require.Equal(t, "2.5%", string(text))
+}
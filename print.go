@@ -0,0 +1,456 @@
+package decimal
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/ericlagergren/decimal"
+)
+
+// coefficient returns the digits and exponent of the absolute value of the
+// finite d, d = digits × 10^exp
+func coefficient(d Decimal) (digits string, exp int) {
+	r := d.native().Rat(nil)
+	r.Abs(r)
+	exp = -d.Scale()
+	n := new(big.Int)
+	if exp <= 0 {
+		n.Mul(r.Num(), new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(-exp)), nil))
+		n.Quo(n, r.Denom())
+	} else {
+		n.Quo(r.Num(), new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(exp)), nil))
+	}
+	return n.String(), exp
+}
+
+// roundDigits rounds digits half to even to keep digits. The result has keep
+// digits, or one more if rounding carried into a new leading digit.
+func roundDigits(digits string, keep int) string {
+	if keep >= len(digits) {
+		return digits + strings.Repeat("0", keep-len(digits))
+	}
+	if keep < 0 {
+		return "0"
+	}
+	head, tail := digits[:keep], digits[keep:]
+	up := false
+	switch half := "5" + strings.Repeat("0", len(tail)-1); {
+	case tail > half:
+		up = true
+	case tail == half:
+		up = keep > 0 && (head[keep-1]-'0')%2 == 1
+	}
+	if !up {
+		if head == "" {
+			return "0"
+		}
+		return head
+	}
+	b := []byte("0" + head)
+	i := len(b) - 1
+	for ; b[i] == '9'; i-- {
+		b[i] = '0'
+	}
+	b[i]++
+	if b[0] == '0' {
+		b = b[1:]
+	}
+	return string(b)
+}
+
+// plain writes digits × 10^exp without exponent
+func plain(digits string, exp int) string {
+	if exp >= 0 {
+		if digits == "0" {
+			return "0"
+		}
+		return digits + strings.Repeat("0", exp)
+	}
+	scale := -exp
+	if len(digits) <= scale {
+		digits = strings.Repeat("0", scale-len(digits)+1) + digits
+	}
+	return digits[:len(digits)-scale] + "." + digits[len(digits)-scale:]
+}
+
+// fixed writes digits × 10^exp rounded to prec fractional digits
+func fixed(digits string, exp, prec int) string {
+	if exp < -prec {
+		digits = roundDigits(digits, len(digits)+exp+prec)
+	} else {
+		digits += strings.Repeat("0", exp+prec)
+	}
+	return plain(digits, -prec)
+}
+
+// scientific writes digits × 10^exp as d.ddde±xx with prec fractional digits,
+// or all digits if prec is negative
+func scientific(digits string, exp, prec int, e byte, sharp bool) string {
+	if digits == "0" {
+		exp = 0
+	} else if prec >= 0 {
+		rounded := roundDigits(digits, prec+1)
+		exp += len(digits) - (prec + 1)
+		digits = rounded
+		if len(digits) > prec+1 {
+			digits, exp = digits[:prec+1], exp+1
+		}
+	}
+	adjusted := exp + len(digits) - 1
+	if prec >= 0 && len(digits) < prec+1 {
+		digits += strings.Repeat("0", prec+1-len(digits))
+	}
+	var b strings.Builder
+	b.WriteByte(digits[0])
+	if len(digits) > 1 || sharp {
+		b.WriteByte('.')
+	}
+	b.WriteString(digits[1:])
+	b.WriteByte(e)
+	if adjusted < 0 {
+		b.WriteByte('-')
+		adjusted = -adjusted
+	} else {
+		b.WriteByte('+')
+	}
+	if adjusted < 10 {
+		b.WriteByte('0')
+	}
+	b.WriteString(strconv.Itoa(adjusted))
+	return b.String()
+}
+
+// general writes digits × 10^exp like strconv's %g, in scientific notation
+// for large and small exponents, else without. prec is the number of
+// significant digits, negative for all digits. Then the scientific notation is
+// used for positive exponents, 1E+6 but not 1000000, as well as below 1e-4.
+func general(digits string, exp, prec int, e byte, sharp bool) string {
+	if prec == 0 {
+		prec = 1
+	}
+	if prec > 0 && digits == "0" && !sharp {
+		return "0"
+	}
+	eprec := len(digits)
+	if prec > 0 && digits != "0" {
+		rounded := roundDigits(digits, prec)
+		exp += len(digits) - prec
+		digits = rounded
+		if len(digits) > prec {
+			digits, exp = digits[:prec], exp+1
+		}
+		if !sharp {
+			trimmed := strings.TrimRight(digits, "0")
+			exp += len(digits) - len(trimmed)
+			digits = trimmed
+		}
+		eprec = prec
+		if eprec > len(digits) && exp <= 0 {
+			eprec = len(digits)
+		}
+	}
+	adjusted := exp + len(digits) - 1
+	if digits != "0" && (adjusted < -4 || adjusted >= eprec) {
+		return scientific(digits, exp, len(digits)-1, e, sharp)
+	}
+	return plain(digits, exp)
+}
+
+// Format implements fmt.Formatter. The verbs are
+//
+//	%v, %s  as String, or %g if a precision is given
+//	%q      String quoted, with # in backquotes
+//	%f, %F  without exponent, the precision is the number of fractional digits
+//	%e, %E  in scientific notation, the precision is the number of fractional digits
+//	%g, %G  like %e for large and small exponents, else like %f, the precision
+//	        is the number of significant digits
+//	%#v     as Go expression
+//
+// Without precision %f, %e and %g write all digits of d, so 1.50 keeps its
+// trailing zero and 0.00 is "0.00" while %v and %s give "0" like String.
+// Values are rounded half to even. The flags + and space control the sign,
+// though like for floats %+v adds no plus sign. The flag - pads on the right
+// and 0 pads with leading zeros. NaN and Infinity are written like String
+// with any verb.
+func (d Decimal) Format(s fmt.State, verb rune) {
+	prec, hasPrec := s.Precision()
+	if !hasPrec {
+		prec = -1
+	}
+	sharp := s.Flag('#')
+	if verb == 'v' && sharp {
+		d.pad(s, "", d.GoString(), false)
+		return
+	}
+	if (verb == 'v' || verb == 's') && hasPrec {
+		verb = 'g'
+	}
+
+	finite := d.native().IsFinite()
+	var body string
+	negative := false
+	switch {
+	case verb == 'q':
+		if sharp {
+			d.pad(s, "", "`"+d.String()+"`", false)
+		} else {
+			d.pad(s, "", strconv.Quote(d.String()), false)
+		}
+		return
+	case verb != 'v' && verb != 's' && verb != 'f' && verb != 'F' &&
+		verb != 'e' && verb != 'E' && verb != 'g' && verb != 'G':
+		fmt.Fprintf(s, "%%!%c(decimal.Decimal=%s)", verb, d.String())
+		return
+	case d.IsNaN():
+		body = "NaN"
+	case !finite:
+		body, negative = "Infinity", d.native().Signbit()
+	default:
+		digits, exp := coefficient(d)
+		switch verb {
+		case 'v', 's':
+			body = d.String()
+			negative = strings.HasPrefix(body, "-")
+			body = strings.TrimPrefix(body, "-")
+		case 'f', 'F':
+			if prec < 0 {
+				body = plain(digits, exp)
+			} else {
+				body = fixed(digits, exp, prec)
+			}
+		case 'e', 'E':
+			body = scientific(digits, exp, prec, byte(verb), sharp)
+		case 'g', 'G':
+			body = general(digits, exp, prec, byte(verb-'g'+'e'), sharp)
+		}
+		if verb != 'v' && verb != 's' {
+			// a value rounded to zero loses its sign like in String
+			negative = d.native().Sign() < 0 && strings.Trim(body, "0.eE+-") != ""
+		}
+	}
+
+	sign := ""
+	switch {
+	case body == "NaN":
+	case negative:
+		sign = "-"
+	case s.Flag('+') && verb != 'v':
+		sign = "+"
+	case s.Flag(' '):
+		sign = " "
+	}
+	d.pad(s, sign, body, finite)
+}
+
+// pad writes sign and body to s padded to the width of s
+func (Decimal) pad(s fmt.State, sign, body string, zeros bool) {
+	width, ok := s.Width()
+	n := utf8.RuneCountInString(sign) + utf8.RuneCountInString(body)
+	if !ok || width <= n {
+		fmt.Fprint(s, sign, body)
+		return
+	}
+	fill := width - n
+	switch {
+	case s.Flag('-'):
+		fmt.Fprint(s, sign, body, strings.Repeat(" ", fill))
+	case s.Flag('0') && zeros:
+		fmt.Fprint(s, sign, strings.Repeat("0", fill), body)
+	default:
+		fmt.Fprint(s, strings.Repeat(" ", fill), sign, body)
+	}
+}
+
+// GoString implements fmt.GoStringer, it returns a Go expression that
+// evaluates to d with its scale, such as decimal.MustNewFromString("1.50")
+func (d Decimal) GoString() string {
+	switch {
+	case d.nat == nil:
+		return "decimal.Decimal{}"
+	case d.IsNaN():
+		return "decimal.DivMode(decimal.Zero(), decimal.Zero(), 0, decimal.ToNearestEven)"
+	}
+	return fmt.Sprintf("decimal.MustNewFromString(%q)", d.nat.String())
+}
+
+// StringFixed returns d with exactly places fractional digits, rounded half to
+// even or padded with zeros, such as "5.00" for 5 and places 2. Negative places
+// round to tens, hundreds and so on. d is not modified.
+func (d Decimal) StringFixed(places int) string {
+	return string(d.appendFixed(nil, places, ToNearestEven))
+}
+
+// StringFixedMode is like StringFixed but rounds with mode
+func (d Decimal) StringFixedMode(places int, mode RoundingMode) string {
+	return string(d.appendFixed(nil, places, mode))
+}
+
+// StringFixedCash returns d rounded to a multiple of increment with
+// ToNearestAway, as cash registers do, with exactly places fractional digits,
+// such as "1.05" for 1.025 with increment 0.05 and places 2. If increment has
+// more fractional digits than places the multiple is rounded to places with
+// ToNearestAway as well, 1.05 with increment 0.05 and places 1 is "1.1".
+func (d Decimal) StringFixedCash(places int, increment Decimal) string {
+	return RoundToIncrement(d, increment, ToNearestAway).StringFixedMode(places, ToNearestAway)
+}
+
+// AppendFixed appends d formatted like StringFixed to dst and returns the
+// extended buffer. It does not allocate if dst has enough capacity and the
+// coefficient of d fits in 64 bits.
+func (d Decimal) AppendFixed(dst []byte, places int) []byte {
+	return d.appendFixed(dst, places, ToNearestEven)
+}
+
+var pow10s = [...]uint64{1, 1e1, 1e2, 1e3, 1e4, 1e5, 1e6, 1e7, 1e8, 1e9, 1e10,
+	1e11, 1e12, 1e13, 1e14, 1e15, 1e16, 1e17, 1e18, 1e19}
+
+func (d Decimal) appendFixed(dst []byte, places int, mode RoundingMode) []byte {
+	x := d.native()
+	switch {
+	case x.IsNaN(0):
+		return append(dst, "NaN"...)
+	case !x.IsFinite() && x.Signbit():
+		return append(dst, "-Infinity"...)
+	case !x.IsFinite():
+		return append(dst, "Infinity"...)
+	}
+
+	// fast path for coefficients of up to 64 bits
+	var mant decimal.Big
+	mant.Copy(x)
+	mant.SetScale(0)
+	if v, ok := mant.Int64(); ok && v != math.MinInt64 && places >= 0 {
+		negative := v < 0
+		u := uint64(v)
+		if negative {
+			u = uint64(-v)
+		}
+		var buf [64]byte
+		digits := buf[:0]
+		switch shift := x.Scale() - places; {
+		case shift >= len(pow10s):
+			digits = nil
+		case shift > 0:
+			q, rem := u/pow10s[shift], u%pow10s[shift]
+			if rem != 0 && mode.roundsUpUint(q, rem, pow10s[shift], !negative) {
+				q++
+			}
+			digits = strconv.AppendUint(digits, q, 10)
+		case -shift <= len(buf)-20:
+			digits = strconv.AppendUint(digits, u, 10)
+			for i := shift; i < 0; i++ {
+				digits = append(digits, '0')
+			}
+		default:
+			digits = nil
+		}
+		if digits != nil {
+			return appendPlain(dst, negative, digits, places)
+		}
+	}
+
+	y := newFromRat(x.Rat(nil), places, mode)
+	digits, exp := coefficient(y)
+	if places < 0 {
+		digits += strings.Repeat("0", exp)
+		places = 0
+	}
+	return appendPlain(dst, y.native().Sign() < 0, []byte(digits), places)
+}
+
+// appendPlain appends digits × 10^-places with its sign to dst, zeros have
+// no sign
+func appendPlain(dst []byte, negative bool, digits []byte, places int) []byte {
+	for len(digits) > 1 && digits[0] == '0' {
+		digits = digits[1:]
+	}
+	if negative && !(len(digits) == 1 && digits[0] == '0') {
+		dst = append(dst, '-')
+	}
+	if len(digits) <= places {
+		dst = append(dst, '0', '.')
+		for i := len(digits); i < places; i++ {
+			dst = append(dst, '0')
+		}
+		return append(dst, digits...)
+	}
+	dst = append(dst, digits[:len(digits)-places]...)
+	if places > 0 {
+		dst = append(dst, '.')
+		dst = append(dst, digits[len(digits)-places:]...)
+	}
+	return dst
+}
+
+// ToSci returns d in scientific notation as defined by the to-scientific-string
+// operation of the General Decimal Arithmetic specification. It keeps the
+// exponent of d, 1.50 is "1.50", 1E+6 is "1E+6", 0.00000032 is "3.2E-7".
+// NewFromString parses the result to a decimal with the same digits and
+// exponent.
+func (d Decimal) ToSci() string {
+	return d.toSpecString(false)
+}
+
+// ToEng is like ToSci but follows the to-engineering-string operation, the
+// exponents are multiples of three, 0.00000032 is "320E-9". NewFromString
+// parses the result to an equal decimal that returns the same ToEng, but
+// possibly with another exponent, 7E-7 is "700E-9".
+func (d Decimal) ToEng() string {
+	return d.toSpecString(true)
+}
+
+func (d Decimal) toSpecString(eng bool) string {
+	x := d.native()
+	sign := ""
+	if x.Signbit() {
+		sign = "-"
+	}
+	switch {
+	case x.IsNaN(0):
+		return "NaN"
+	case !x.IsFinite():
+		return sign + "Infinity"
+	}
+	digits, exp := coefficient(d)
+	leftDigits := exp + len(digits)
+	var dot int
+	switch {
+	case exp <= 0 && leftDigits > -6:
+		dot = leftDigits
+	case !eng:
+		dot = 1
+	case digits == "0":
+		dot = mod3(leftDigits+1) - 1
+	default:
+		dot = mod3(leftDigits-1) + 1
+	}
+
+	var b strings.Builder
+	b.WriteString(sign)
+	switch {
+	case dot <= 0:
+		b.WriteString("0.")
+		b.WriteString(strings.Repeat("0", -dot))
+		b.WriteString(digits)
+	case dot >= len(digits):
+		b.WriteString(digits)
+		b.WriteString(strings.Repeat("0", dot-len(digits)))
+	default:
+		b.WriteString(digits[:dot])
+		b.WriteByte('.')
+		b.WriteString(digits[dot:])
+	}
+	if leftDigits != dot {
+		fmt.Fprintf(&b, "E%+d", leftDigits-dot)
+	}
+	return b.String()
+}
+
+// mod3 returns n modulo 3 in [0, 3)
+func mod3(n int) int {
+	return ((n % 3) + 3) % 3
+}
@@ -0,0 +1,248 @@
+package decimal_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/talon-one/decimal"
+)
+
+func TestFormatVerbs(t *testing.T) {
+	testData := []struct {
+		format   string
+		value    string
+		expected string
+	}{
+		{format: "%v", value: "1.50", expected: "1.50"},
+		{format: "%s", value: "-1234.5678", expected: "-1234.5678"},
+		{format: "%v", value: "1E+6", expected: "1000000"},
+		{format: "%v", value: "0.00", expected: "0"},
+		{format: "%s", value: "-0.00", expected: "0"},
+		{format: "%f", value: "0.00", expected: "0.00"},
+		{format: "%v", value: "-0", expected: "0"},
+		{format: "%+v", value: "1.5", expected: "1.5"},
+		{format: "%+s", value: "1.5", expected: "+1.5"},
+		{format: "% v", value: "1.5", expected: " 1.5"},
+		{format: "% v", value: "-1.5", expected: "-1.5"},
+		{format: "%.3v", value: "1234.5678", expected: "1.23e+03"},
+		{format: "%q", value: "1.50", expected: `"1.50"`},
+		{format: "%#q", value: "-2", expected: "`-2`"},
+		{format: "%8q", value: "1.5", expected: `   "1.5"`},
+
+		{format: "%f", value: "1.50", expected: "1.50"},
+		{format: "%f", value: "1E+6", expected: "1000000"},
+		{format: "%f", value: "0.00", expected: "0.00"},
+		{format: "%F", value: "1.23E-7", expected: "0.000000123"},
+		{format: "%.2f", value: "0", expected: "0.00"},
+		{format: "%.2f", value: "1.005", expected: "1.00"},
+		{format: "%.2f", value: "1.015", expected: "1.02"},
+		{format: "%.2f", value: "1.0051", expected: "1.01"},
+		{format: "%.2f", value: "-0.001", expected: "0.00"},
+		{format: "%.2f", value: "99.999", expected: "100.00"},
+		{format: "%.0f", value: "0.5", expected: "0"},
+		{format: "%.0f", value: "1.5", expected: "2"},
+		{format: "%.3f", value: "1E+2", expected: "100.000"},
+		{format: "%.2f", value: "12345678901234567890.125", expected: "12345678901234567890.12"},
+
+		{format: "%e", value: "1234.5678", expected: "1.2345678e+03"},
+		{format: "%e", value: "1.50", expected: "1.50e+00"},
+		{format: "%E", value: "-1.23E-7", expected: "-1.23E-07"},
+		{format: "%e", value: "0", expected: "0e+00"},
+		{format: "%.2e", value: "1234.5678", expected: "1.23e+03"},
+		{format: "%.2e", value: "9.999", expected: "1.00e+01"},
+		{format: "%.3e", value: "0", expected: "0.000e+00"},
+		{format: "%.0e", value: "25", expected: "2e+01"},
+		{format: "%#.0e", value: "35", expected: "4.e+01"},
+		{format: "%e", value: "1E+100", expected: "1e+100"},
+
+		{format: "%g", value: "1234.5678", expected: "1234.5678"},
+		{format: "%g", value: "1.50", expected: "1.50"},
+		{format: "%g", value: "1E+6", expected: "1e+06"},
+		{format: "%g", value: "1000000", expected: "1000000"},
+		{format: "%g", value: "0.0001", expected: "0.0001"},
+		{format: "%G", value: "0.00001", expected: "1E-05"},
+		{format: "%.3g", value: "1234.5678", expected: "1.23e+03"},
+		{format: "%.3g", value: "1.5", expected: "1.5"},
+		{format: "%#.3g", value: "1.5", expected: "1.50"},
+		{format: "%.4g", value: "1234.5678", expected: "1235"},
+		{format: "%.3g", value: "0.00", expected: "0"},
+		{format: "%.10g", value: "0.000012345", expected: "1.2345e-05"},
+
+		{format: "%10.2f", value: "-1.5", expected: "     -1.50"},
+		{format: "%-10.2f|", value: "-1.5", expected: "-1.50     |"},
+		{format: "%010.2f", value: "-1.5", expected: "-000001.50"},
+		{format: "%+010.2f", value: "1.5", expected: "+000001.50"},
+		{format: "%-010.2f|", value: "1.5", expected: "1.50      |"},
+		{format: "% 8.1f", value: "2.25", expected: "     2.2"},
+		{format: "%08v", value: "1.5", expected: "000001.5"},
+		{format: "%2v", value: "123.4", expected: "123.4"},
+		{format: "%010v", value: "-Infinity", expected: " -Infinity"},
+		{format: "%+.2f", value: "Infinity", expected: "+Infinity"},
+		{format: "%e", value: "-Infinity", expected: "-Infinity"},
+
+		{format: "%d", value: "1.5", expected: "%!d(decimal.Decimal=1.5)"},
+		{format: "%#v", value: "1.50", expected: `decimal.MustNewFromString("1.50")`},
+		{format: "%#v", value: "1E+6", expected: `decimal.MustNewFromString("1E+6")`},
+		{format: "%#v", value: "-Infinity", expected: `decimal.MustNewFromString("-Infinity")`},
+	}
+	for i, j := range testData {
+		data := setup(j.value)
+		require.Equal(t, j.expected, fmt.Sprintf(j.format, data.Decimals[0]), "At %d", i)
+		data.VerifyIntegrity(t)
+	}
+}
+
+func TestFormatSpecial(t *testing.T) {
+	nan := decimal.DivMode(decimal.NewFromInt(1), decimal.Zero(), 2, decimal.ToNearestEven)
+	require.Equal(t, "NaN", nan.String())
+	require.Equal(t, "NaN NaN   NaN", fmt.Sprintf("%v %.2f %5e", nan, nan, nan))
+	require.Equal(t, "decimal.DivMode(decimal.Zero(), decimal.Zero(), 0, decimal.ToNearestEven)", fmt.Sprintf("%#v", nan))
+	require.True(t, decimal.DivMode(decimal.Zero(), decimal.Zero(), 0, decimal.ToNearestEven).IsNaN())
+
+	var zero decimal.Decimal
+	require.Equal(t, "decimal.Decimal{}", fmt.Sprintf("%#v", zero))
+	require.Equal(t, "0 0.00", fmt.Sprintf("%v %.2f", zero, zero))
+
+	s := struct{ A decimal.Decimal }{decimal.MustNewFromString("1.5")}
+	require.Equal(t, "{A:1.5}", fmt.Sprintf("%+v", s))
+	require.Equal(t, `struct { A decimal.Decimal }{A:decimal.MustNewFromString("1.5")}`, fmt.Sprintf("%#v", s))
+}
+
+func TestFormatConsistentWithString(t *testing.T) {
+	for i, v := range []string{"0", "0.00", "-0", "1", "-1.50", "1E+6", "1.23E-7", "-12345678901234567890.123", "Infinity", "-Infinity"} {
+		d := decimal.MustNewFromString(v)
+		require.Equal(t, d.String(), fmt.Sprint(d), "At %d", i)
+		require.Equal(t, d.String(), fmt.Sprintf("%s", d), "At %d", i)
+		require.Equal(t, fmt.Sprintf("%q", d.String()), fmt.Sprintf("%q", d), "At %d", i)
+
+		goSyntax := fmt.Sprintf("%#v", d)
+		arg := strings.TrimSuffix(strings.TrimPrefix(goSyntax, `decimal.MustNewFromString("`), `")`)
+		parsed := decimal.MustNewFromString(arg)
+		require.Zero(t, d.Cmp(parsed), "At %d", i)
+		require.Equal(t, d.Scale(), parsed.Scale(), "At %d", i)
+	}
+}
+
+func TestStringFixed(t *testing.T) {
+	testData := []struct {
+		value    string
+		places   int
+		mode     decimal.RoundingMode
+		expected string
+	}{
+		{value: "5", places: 2, expected: "5.00"},
+		{value: "-0.1", places: 2, expected: "-0.10"},
+		{value: "0", places: 2, expected: "0.00"},
+		{value: "0.000", places: 1, expected: "0.0"},
+		{value: "1.005", places: 2, expected: "1.00"},
+		{value: "1.015", places: 2, expected: "1.02"},
+		{value: "1.005", places: 2, mode: decimal.ToNearestAway, expected: "1.01"},
+		{value: "1.001", places: 2, mode: decimal.AwayFromZero, expected: "1.01"},
+		{value: "-1.001", places: 2, mode: decimal.ToNegativeInf, expected: "-1.01"},
+		{value: "-1.009", places: 2, mode: decimal.ToPositiveInf, expected: "-1.00"},
+		{value: "-1.009", places: 2, mode: decimal.ToZero, expected: "-1.00"},
+		{value: "-0.001", places: 2, expected: "0.00"},
+		{value: "99.995", places: 2, expected: "100.00"},
+		{value: "-99.995", places: 2, expected: "-100.00"},
+		{value: "1E+6", places: 2, expected: "1000000.00"},
+		{value: "1.23E-7", places: 9, expected: "0.000000123"},
+		{value: "1234.5", places: 0, expected: "1234"},
+		{value: "1235.5", places: 0, expected: "1236"},
+		{value: "1250", places: -2, expected: "1200"},
+		{value: "1350", places: -2, expected: "1400"},
+		{value: "40", places: -2, expected: "0"},
+		{value: "12345678901234567890.125", places: 2, expected: "12345678901234567890.12"},
+		{value: "12345678901234567890.125", places: 2, mode: decimal.ToNearestAway, expected: "12345678901234567890.13"},
+		{value: "0.12345678901234567890123", places: 22, expected: "0.1234567890123456789012"},
+		{value: "1.5", places: 30, expected: "1.500000000000000000000000000000"},
+		{value: "-Infinity", places: 2, expected: "-Infinity"},
+	}
+	for i, j := range testData {
+		data := setup(j.value)
+		require.Equal(t, j.expected, data.Decimals[0].StringFixedMode(j.places, j.mode), "At %d", i)
+		if j.mode == decimal.ToNearestEven {
+			require.Equal(t, j.expected, data.Decimals[0].StringFixed(j.places), "At %d", i)
+			require.Equal(t, "x"+j.expected, string(data.Decimals[0].AppendFixed([]byte("x"), j.places)), "At %d", i)
+			if j.places >= 0 {
+				require.Equal(t, fmt.Sprintf("%.*f", j.places, data.Decimals[0]), j.expected, "At %d", i)
+			}
+		}
+		data.VerifyIntegrity(t)
+	}
+}
+
+func TestStringFixedCash(t *testing.T) {
+	data := setup("1.025", "1.024", "-1.025", "0.05", "1", "1.05", "0.25")
+	require.Equal(t, "1.05", data.Decimals[0].StringFixedCash(2, data.Decimals[3]))
+	require.Equal(t, "1.00", data.Decimals[1].StringFixedCash(2, data.Decimals[3]))
+	require.Equal(t, "-1.05", data.Decimals[2].StringFixedCash(2, data.Decimals[3]))
+	require.Equal(t, "1.000", data.Decimals[1].StringFixedCash(3, data.Decimals[4]))
+	require.Equal(t, "NaN", data.Decimals[1].StringFixedCash(2, decimal.Zero()))
+	require.Equal(t, "1.1", data.Decimals[5].StringFixedCash(1, data.Decimals[3]))
+	require.Equal(t, "1", data.Decimals[5].StringFixedCash(0, data.Decimals[3]))
+	require.Equal(t, "1.0", data.Decimals[5].StringFixedCash(1, data.Decimals[6]))
+	require.Equal(t, "-1.1", data.Decimals[2].StringFixedCash(1, data.Decimals[3]))
+	data.VerifyIntegrity(t)
+}
+
+func TestAppendFixedAllocations(t *testing.T) {
+	d := decimal.MustNewFromString("-1234.5678")
+	buf := make([]byte, 0, 64)
+	allocs := testing.AllocsPerRun(100, func() {
+		buf = d.AppendFixed(buf[:0], 2)
+	})
+	require.Equal(t, "-1234.57", string(buf))
+	require.Zero(t, allocs)
+}
+
+func TestToSciAndEng(t *testing.T) {
+	testData := []struct {
+		value string
+		sci   string
+		eng   string
+	}{
+		{value: "123", sci: "123", eng: "123"},
+		{value: "-123", sci: "-123", eng: "-123"},
+		{value: "1.50", sci: "1.50", eng: "1.50"},
+		{value: "123E+1", sci: "1.23E+3", eng: "1.23E+3"},
+		{value: "123E+3", sci: "1.23E+5", eng: "123E+3"},
+		{value: "123E-10", sci: "1.23E-8", eng: "12.3E-9"},
+		{value: "-123E-12", sci: "-1.23E-10", eng: "-123E-12"},
+		{value: "7E-7", sci: "7E-7", eng: "700E-9"},
+		{value: "7E+1", sci: "7E+1", eng: "70"},
+		{value: "0.00000032", sci: "3.2E-7", eng: "320E-9"},
+		{value: "0.000001", sci: "0.000001", eng: "0.000001"},
+		{value: "0.0000001", sci: "1E-7", eng: "100E-9"},
+		{value: "0.00", sci: "0.00", eng: "0.00"},
+		{value: "0E+1", sci: "0E+1", eng: "0.00E+3"},
+		{value: "0E+2", sci: "0E+2", eng: "0.0E+3"},
+		{value: "0E+3", sci: "0E+3", eng: "0E+3"},
+		{value: "0E-7", sci: "0E-7", eng: "0.0E-6"},
+		{value: "-0", sci: "-0", eng: "-0"},
+		{value: "1E+6", sci: "1E+6", eng: "1E+6"},
+		{value: "1000000", sci: "1000000", eng: "1000000"},
+		{value: "12345678901234567890E-30", sci: "1.2345678901234567890E-11", eng: "12.345678901234567890E-12"},
+		{value: "Infinity", sci: "Infinity", eng: "Infinity"},
+		{value: "-Infinity", sci: "-Infinity", eng: "-Infinity"},
+	}
+	for i, j := range testData {
+		data := setup(j.value)
+		d := data.Decimals[0]
+		require.Equal(t, j.sci, d.ToSci(), "At %d", i)
+		require.Equal(t, j.eng, d.ToEng(), "At %d", i)
+		parsed, err := decimal.NewFromString(d.ToSci())
+		require.NoError(t, err, "At %d", i)
+		require.Equal(t, j.sci, parsed.ToSci(), "At %d", i)
+		require.Equal(t, d.Scale(), parsed.Scale(), "At %d", i)
+		parsed, err = decimal.NewFromString(d.ToEng())
+		require.NoError(t, err, "At %d", i)
+		require.Equal(t, j.eng, parsed.ToEng(), "At %d", i)
+		require.Zero(t, d.Cmp(parsed), "At %d", i)
+		data.VerifyIntegrity(t)
+	}
+	nan := decimal.DivMode(decimal.NewFromInt(1), decimal.Zero(), 2, decimal.ToNearestEven)
+	require.Equal(t, "NaN", nan.ToSci())
+	require.Equal(t, "NaN", nan.ToEng())
+}
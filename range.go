@@ -0,0 +1,332 @@
+package decimal
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+type boundKind uint8
+
+const (
+	unbounded boundKind = iota
+	inclusive
+	exclusive
+)
+
+// Bound is one endpoint of a Range. The zero value is an unbounded endpoint.
+type Bound struct {
+	value Decimal
+	kind  boundKind
+}
+
+// Inclusive returns a closed endpoint at d, d itself is part of the range.
+func Inclusive(d Decimal) Bound {
+	return Bound{value: NewFromDecimal(d), kind: inclusive}
+}
+
+// Exclusive returns an open endpoint at d, d itself is not part of the range.
+func Exclusive(d Decimal) Bound {
+	return Bound{value: NewFromDecimal(d), kind: exclusive}
+}
+
+// Unbounded returns an endpoint that extends to infinity.
+func Unbounded() Bound {
+	return Bound{}
+}
+
+// IsUnbounded returns true if the endpoint extends to infinity.
+func (b Bound) IsUnbounded() bool {
+	return b.kind == unbounded
+}
+
+// IsInclusive returns true if the endpoint value is part of the range.
+func (b Bound) IsInclusive() bool {
+	return b.kind == inclusive
+}
+
+// Value returns a copy of the endpoint value, it is zero for unbounded endpoints.
+func (b Bound) Value() Decimal {
+	return NewFromDecimal(b.value)
+}
+
+// Range is an interval of decimals with open, closed or unbounded endpoints.
+// The zero value is the range of all decimals, (-inf, +inf).
+type Range struct {
+	lower Bound
+	upper Bound
+}
+
+// NewRange returns the range between lower and upper
+func NewRange(lower, upper Bound) Range {
+	return Range{lower: lower, upper: upper}
+}
+
+// ParseRange parses a range written in interval notation, such as "[50, 100)",
+// "(0, +inf)" or "(-inf, 10]". Unbounded endpoints must be open.
+func ParseRange(s string) (Range, error) {
+	s = strings.TrimSpace(s)
+	if len(s) < 2 {
+		return Range{}, fmt.Errorf("Invalid range `%s'", s)
+	}
+	opening, closing := s[0], s[len(s)-1]
+	if (opening != '[' && opening != '(') || (closing != ']' && closing != ')') {
+		return Range{}, fmt.Errorf("Invalid range `%s': must be enclosed in brackets or parentheses", s)
+	}
+	parts := strings.Split(s[1:len(s)-1], ",")
+	if len(parts) != 2 {
+		return Range{}, fmt.Errorf("Invalid range `%s': expected two endpoints", s)
+	}
+	lower, err := parseBound(parts[0], opening == '[', -1)
+	if err != nil {
+		return Range{}, fmt.Errorf("Invalid range `%s': %v", s, err)
+	}
+	upper, err := parseBound(parts[1], closing == ']', 1)
+	if err != nil {
+		return Range{}, fmt.Errorf("Invalid range `%s': %v", s, err)
+	}
+	return Range{lower: lower, upper: upper}, nil
+}
+
+// MustParseRange is like ParseRange but panics if s is not a valid range
+func MustParseRange(s string) Range {
+	r, err := ParseRange(s)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+func parseBound(s string, closed bool, sign int) (Bound, error) {
+	s = strings.TrimSpace(s)
+	switch s {
+	case "inf", "Inf", "∞", "+inf", "+Inf", "+∞", "-inf", "-Inf", "-∞":
+		if (s[0] == '-') != (sign < 0) {
+			return Bound{}, fmt.Errorf("infinity `%s' on the wrong side", s)
+		}
+		if closed {
+			return Bound{}, errors.New("unbounded endpoint must be open")
+		}
+		return Unbounded(), nil
+	}
+	d, err := NewFromString(s)
+	if err != nil {
+		return Bound{}, fmt.Errorf("endpoint `%s': %v", s, err)
+	}
+	if d.native().IsInf(0) {
+		return Bound{}, fmt.Errorf("endpoint `%s': use inf for unbounded endpoints", s)
+	}
+	if closed {
+		return Bound{value: d, kind: inclusive}, nil
+	}
+	return Bound{value: d, kind: exclusive}, nil
+}
+
+// Lower returns the lower endpoint of r
+func (r Range) Lower() Bound {
+	return r.lower
+}
+
+// Upper returns the upper endpoint of r
+func (r Range) Upper() Bound {
+	return r.upper
+}
+
+// Contains returns true if d lies within r
+func (r Range) Contains(d Decimal) bool {
+	return r.aboveLower(d) && r.belowUpper(d)
+}
+
+func (r Range) aboveLower(d Decimal) bool {
+	switch r.lower.kind {
+	case inclusive:
+		return d.Cmp(r.lower.value) >= 0
+	case exclusive:
+		return d.Cmp(r.lower.value) > 0
+	}
+	return true
+}
+
+func (r Range) belowUpper(d Decimal) bool {
+	switch r.upper.kind {
+	case inclusive:
+		return d.Cmp(r.upper.value) <= 0
+	case exclusive:
+		return d.Cmp(r.upper.value) < 0
+	}
+	return true
+}
+
+// IsEmpty returns true if no decimal lies within r, for example [5, 1] or [3, 3).
+func (r Range) IsEmpty() bool {
+	if r.lower.IsUnbounded() || r.upper.IsUnbounded() {
+		return false
+	}
+	c := r.lower.value.Cmp(r.upper.value)
+	if c == 0 {
+		return !(r.lower.IsInclusive() && r.upper.IsInclusive())
+	}
+	return c > 0
+}
+
+// Clamp returns d limited to the endpoints of r as a new instance.
+// Values outside of r are moved to the nearest endpoint value, even if that
+// endpoint is open. d will not be modified.
+func (r Range) Clamp(d Decimal) Decimal {
+	if !r.lower.IsUnbounded() && d.Cmp(r.lower.value) < 0 {
+		return NewFromDecimal(r.lower.value)
+	}
+	if !r.upper.IsUnbounded() && d.Cmp(r.upper.value) > 0 {
+		return NewFromDecimal(r.upper.value)
+	}
+	return NewFromDecimal(d)
+}
+
+// Intersect returns the range of decimals lying within both r and o.
+// The result may be empty.
+func (r Range) Intersect(o Range) Range {
+	lower := r.lower
+	if cmpLower(o.lower, r.lower) > 0 {
+		lower = o.lower
+	}
+	upper := r.upper
+	if cmpUpper(o.upper, r.upper) < 0 {
+		upper = o.upper
+	}
+	return Range{lower: lower, upper: upper}
+}
+
+// Overlaps returns true if at least one decimal lies within both r and o
+func (r Range) Overlaps(o Range) bool {
+	return !r.Intersect(o).IsEmpty()
+}
+
+// Union returns the range of decimals lying within r or o. It fails if r and o
+// neither overlap nor touch, as the result could not be expressed as a single range.
+func (r Range) Union(o Range) (Range, error) {
+	if r.IsEmpty() {
+		return o, nil
+	}
+	if o.IsEmpty() {
+		return r, nil
+	}
+	if !r.Overlaps(o) && !r.touches(o) && !o.touches(r) {
+		return Range{}, fmt.Errorf("Ranges %s and %s are disjoint", r, o)
+	}
+	lower := r.lower
+	if cmpLower(o.lower, r.lower) < 0 {
+		lower = o.lower
+	}
+	upper := r.upper
+	if cmpUpper(o.upper, r.upper) > 0 {
+		upper = o.upper
+	}
+	return Range{lower: lower, upper: upper}, nil
+}
+
+// touches returns true if the upper endpoint of r meets the lower endpoint of o
+// without a gap, such as [1, 2) and [2, 3].
+func (r Range) touches(o Range) bool {
+	if r.upper.IsUnbounded() || o.lower.IsUnbounded() {
+		return false
+	}
+	return r.upper.value.Equals(o.lower.value) && (r.upper.IsInclusive() || o.lower.IsInclusive())
+}
+
+// cmpLower compares two lower endpoints, the smaller one admits more values.
+func cmpLower(a, b Bound) int {
+	switch {
+	case a.IsUnbounded() && b.IsUnbounded():
+		return 0
+	case a.IsUnbounded():
+		return -1
+	case b.IsUnbounded():
+		return 1
+	}
+	if c := a.value.Cmp(b.value); c != 0 {
+		return c
+	}
+	if a.kind == b.kind {
+		return 0
+	}
+	if a.IsInclusive() {
+		return -1
+	}
+	return 1
+}
+
+// cmpUpper compares two upper endpoints, the larger one admits more values.
+func cmpUpper(a, b Bound) int {
+	switch {
+	case a.IsUnbounded() && b.IsUnbounded():
+		return 0
+	case a.IsUnbounded():
+		return 1
+	case b.IsUnbounded():
+		return -1
+	}
+	if c := a.value.Cmp(b.value); c != 0 {
+		return c
+	}
+	if a.kind == b.kind {
+		return 0
+	}
+	if a.IsInclusive() {
+		return 1
+	}
+	return -1
+}
+
+// String returns r in interval notation, such as "[50, 100)" or "(-inf, 10]"
+func (r Range) String() string {
+	var sb strings.Builder
+	switch r.lower.kind {
+	case unbounded:
+		sb.WriteString("(-inf")
+	case inclusive:
+		sb.WriteString("[" + r.lower.value.String())
+	case exclusive:
+		sb.WriteString("(" + r.lower.value.String())
+	}
+	sb.WriteString(", ")
+	switch r.upper.kind {
+	case unbounded:
+		sb.WriteString("+inf)")
+	case inclusive:
+		sb.WriteString(r.upper.value.String() + "]")
+	case exclusive:
+		sb.WriteString(r.upper.value.String() + ")")
+	}
+	return sb.String()
+}
+
+// MarshalText implements the encoding.TextMarshaler interface for serialization
+func (r Range) MarshalText() ([]byte, error) {
+	return []byte(r.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface for deserialization
+func (r *Range) UnmarshalText(buf []byte) error {
+	tmp, err := ParseRange(string(buf))
+	if err != nil {
+		return err
+	}
+	*r = tmp
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface for serialization.
+// The range is encoded as a JSON string in interval notation.
+func (r Range) MarshalJSON() ([]byte, error) {
+	buf, err := r.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	return []byte(`"` + string(buf) + `"`), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface for deserialization
+func (r *Range) UnmarshalJSON(buf []byte) error {
+	return r.UnmarshalText(bytes.Trim(bytes.TrimSpace(buf), `"`))
+}
@@ -0,0 +1,162 @@
+package decimal_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/talon-one/decimal"
+)
+
+func TestParseRange(t *testing.T) {
+	testData := []struct {
+		input    string
+		expected string
+		err      string
+	}{
+		{input: "[50, 100)", expected: "[50, 100)"},
+		{input: " ( 0.5 ,1.25 ] ", expected: "(0.5, 1.25]"},
+		{input: "(-inf, 10]", expected: "(-inf, 10]"},
+		{input: "[10, ∞)", expected: "[10, +inf)"},
+		{input: "(-Inf, +Inf)", expected: "(-inf, +inf)"},
+		{input: "[-inf, 10]", err: "Invalid range `[-inf, 10]': unbounded endpoint must be open"},
+		{input: "(inf, 10]", err: "Invalid range `(inf, 10]': infinity `inf' on the wrong side"},
+		{input: "(1, Infinity)", err: "Invalid range `(1, Infinity)': endpoint `Infinity': use inf for unbounded endpoints"},
+		{input: "[1, 2", err: "Invalid range `[1, 2': must be enclosed in brackets or parentheses"},
+		{input: "[1, 2, 3]", err: "Invalid range `[1, 2, 3]': expected two endpoints"},
+		{input: "[A, 2]", err: "Invalid range `[A, 2]': endpoint `A': Invalid decimal"},
+		{input: "[", err: "Invalid range `['"},
+	}
+	for i, j := range testData {
+		r, err := decimal.ParseRange(j.input)
+		if j.err != "" {
+			require.EqualError(t, err, j.err, "At %d", i)
+			continue
+		}
+		require.NoError(t, err, "At %d", i)
+		require.Equal(t, j.expected, r.String(), "At %d", i)
+	}
+}
+
+func TestRangeContains(t *testing.T) {
+	testData := []struct {
+		r        string
+		value    string
+		contains bool
+	}{
+		{r: "[50, 100)", value: "50", contains: true},
+		{r: "[50, 100)", value: "99.99", contains: true},
+		{r: "[50, 100)", value: "100", contains: false},
+		{r: "[50, 100)", value: "49.99", contains: false},
+		{r: "(50, 100]", value: "50", contains: false},
+		{r: "(50, 100]", value: "100.0", contains: true},
+		{r: "(-inf, 0)", value: "-1000000", contains: true},
+		{r: "(-inf, 0)", value: "0", contains: false},
+		{r: "(-inf, +inf)", value: "0", contains: true},
+	}
+	for i, j := range testData {
+		data := setup(j.value)
+		require.Equal(t, j.contains, decimal.MustParseRange(j.r).Contains(data.Decimals[0]), "At %d: %s in %s", i, j.value, j.r)
+		data.VerifyIntegrity(t)
+	}
+}
+
+func TestRangeIsEmpty(t *testing.T) {
+	require.False(t, decimal.MustParseRange("[3, 3]").IsEmpty())
+	require.True(t, decimal.MustParseRange("[3, 3)").IsEmpty())
+	require.True(t, decimal.MustParseRange("(3, 3]").IsEmpty())
+	require.True(t, decimal.MustParseRange("[5, 1]").IsEmpty())
+	require.False(t, decimal.MustParseRange("(-inf, 1)").IsEmpty())
+	require.False(t, decimal.Range{}.IsEmpty())
+}
+
+func TestRangeClamp(t *testing.T) {
+	r := decimal.MustParseRange("[50, 100)")
+	data := setup("10", "75", "150")
+	require.Equal(t, "50", r.Clamp(data.Decimals[0]).String())
+	require.Equal(t, "75", r.Clamp(data.Decimals[1]).String())
+	require.Equal(t, "100", r.Clamp(data.Decimals[2]).String())
+	data.VerifyIntegrity(t)
+
+	require.Equal(t, "-10", decimal.MustParseRange("(-inf, 0]").Clamp(decimal.NewFromInt(-10)).String())
+}
+
+func TestRangeIntersectAndOverlaps(t *testing.T) {
+	testData := []struct {
+		a        string
+		b        string
+		expected string
+		overlaps bool
+	}{
+		{a: "[0, 10]", b: "[5, 15]", expected: "[5, 10]", overlaps: true},
+		{a: "[0, 10)", b: "[10, 15]", expected: "[10, 10)", overlaps: false},
+		{a: "[0, 10]", b: "[10, 15]", expected: "[10, 10]", overlaps: true},
+		{a: "(-inf, 10]", b: "(5, +inf)", expected: "(5, 10]", overlaps: true},
+		{a: "[0, 10]", b: "(0, 10)", expected: "(0, 10)", overlaps: true},
+		{a: "[0, 1]", b: "[2, 3]", expected: "[2, 1]", overlaps: false},
+	}
+	for i, j := range testData {
+		a, b := decimal.MustParseRange(j.a), decimal.MustParseRange(j.b)
+		require.Equal(t, j.expected, a.Intersect(b).String(), "At %d", i)
+		require.Equal(t, j.expected, b.Intersect(a).String(), "At %d", i)
+		require.Equal(t, j.overlaps, a.Overlaps(b), "At %d", i)
+		require.Equal(t, j.overlaps, b.Overlaps(a), "At %d", i)
+	}
+}
+
+func TestRangeUnion(t *testing.T) {
+	testData := []struct {
+		a        string
+		b        string
+		expected string
+		err      string
+	}{
+		{a: "[0, 10]", b: "[5, 15)", expected: "[0, 15)"},
+		{a: "[0, 10)", b: "[10, 15]", expected: "[0, 15]"},
+		{a: "[10, 15]", b: "(0, 10]", expected: "(0, 15]"},
+		{a: "(-inf, 0]", b: "[-5, +inf)", expected: "(-inf, +inf)"},
+		{a: "[3, 3)", b: "[5, 6]", expected: "[5, 6]"},
+		{a: "[0, 10)", b: "(10, 15]", err: "Ranges [0, 10) and (10, 15] are disjoint"},
+		{a: "[0, 1]", b: "[2, 3]", err: "Ranges [0, 1] and [2, 3] are disjoint"},
+	}
+	for i, j := range testData {
+		u, err := decimal.MustParseRange(j.a).Union(decimal.MustParseRange(j.b))
+		if j.err != "" {
+			require.EqualError(t, err, j.err, "At %d", i)
+			continue
+		}
+		require.NoError(t, err, "At %d", i)
+		require.Equal(t, j.expected, u.String(), "At %d", i)
+	}
+}
+
+func TestNewRange(t *testing.T) {
+	data := setup("50", "100")
+	r := decimal.NewRange(decimal.Inclusive(data.Decimals[0]), decimal.Exclusive(data.Decimals[1]))
+	require.Equal(t, "[50, 100)", r.String())
+	require.True(t, r.Lower().IsInclusive())
+	require.False(t, r.Upper().IsInclusive())
+	require.Equal(t, "100", r.Upper().Value().String())
+
+	// modifying the inputs does not change the range
+	data.Decimals[0].Add(decimal.NewFromInt(1))
+	require.Equal(t, "[50, 100)", r.String())
+
+	require.Equal(t, "(-inf, 100)", decimal.NewRange(decimal.Unbounded(), decimal.Exclusive(data.Decimals[1])).String())
+}
+
+func TestRangeJSON(t *testing.T) {
+	type tier struct {
+		Name  string
+		Range decimal.Range
+	}
+	buf, err := json.Marshal(tier{Name: "silver", Range: decimal.MustParseRange("[50, 100)")})
+	require.NoError(t, err)
+	require.Equal(t, `{"Name":"silver","Range":"[50, 100)"}`, string(buf))
+
+	var out tier
+	require.NoError(t, json.Unmarshal([]byte(`{"Name":"gold","Range":"[100, +inf)"}`), &out))
+	require.Equal(t, "[100, +inf)", out.Range.String())
+
+	require.Error(t, json.Unmarshal([]byte(`{"Range":"[100"}`), &out))
+}
@@ -0,0 +1,100 @@
+package decimal
+
+import (
+	"math/big"
+
+	"github.com/ericlagergren/decimal"
+)
+
+// RoundingMode determines how a value is rounded when digits are dropped
+type RoundingMode int
+
+const (
+	// ToNearestEven rounds to the nearest value, ties go to the even neighbour (banker's rounding)
+	ToNearestEven RoundingMode = iota
+	// ToNearestAway rounds to the nearest value, ties go away from zero (commercial rounding)
+	ToNearestAway
+	// ToZero drops the digits (truncation)
+	ToZero
+	// AwayFromZero rounds up the magnitude whenever digits are dropped
+	AwayFromZero
+	// ToNegativeInf rounds towards negative infinity (floor)
+	ToNegativeInf
+	// ToPositiveInf rounds towards positive infinity (ceiling)
+	ToPositiveInf
+)
+
+func (m RoundingMode) String() string {
+	return m.native().String()
+}
+
+func (m RoundingMode) native() decimal.RoundingMode {
+	return decimal.RoundingMode(m)
+}
+
+// newFromRat rounds r to the scale, digits, with mode
+func newFromRat(r *big.Rat, digits int, mode RoundingMode) Decimal {
+	num := new(big.Int).Set(r.Num())
+	den := new(big.Int).Set(r.Denom())
+	if digits >= 0 {
+		num.Mul(num, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(digits)), nil))
+	} else {
+		den.Mul(den, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(-digits)), nil))
+	}
+	q, rem := new(big.Int).QuoRem(num, den, new(big.Int))
+	if rem.Sign() != 0 && mode.roundsUp(q, rem, den, r.Sign() > 0) {
+		q.Add(q, big.NewInt(int64(r.Sign())))
+	}
+	d := decimal.New(0, 0)
+	d.SetBigMantScale(q, digits)
+	return Decimal{d}
+}
+
+// roundsUp reports whether the magnitude of the truncated quotient q has to
+// grow by one given the nonzero remainder rem of the division by den.
+func (m RoundingMode) roundsUp(q, rem, den *big.Int, positive bool) bool {
+	switch m {
+	case ToZero:
+		return false
+	case AwayFromZero:
+		return true
+	case ToNegativeInf:
+		return !positive
+	case ToPositiveInf:
+		return positive
+	}
+	half := new(big.Int).Abs(rem)
+	half.Lsh(half, 1)
+	switch c := half.Cmp(den); {
+	case c > 0:
+		return true
+	case c < 0:
+		return false
+	}
+	if m == ToNearestAway {
+		return true
+	}
+	return q.Bit(0) == 1
+}
+
+// roundsUpUint is roundsUp for the truncated quotient q and the nonzero
+// remainder rem of a division by den that fit in 64 bits
+func (m RoundingMode) roundsUpUint(q, rem, den uint64, positive bool) bool {
+	switch m {
+	case ToZero:
+		return false
+	case AwayFromZero:
+		return true
+	case ToNegativeInf:
+		return !positive
+	case ToPositiveInf:
+		return positive
+	}
+	switch other := den - rem; {
+	case rem > other:
+		return true
+	case rem < other:
+		return false
+	}
+	return m == ToNearestAway || q%2 == 1
+}
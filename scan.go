@@ -0,0 +1,93 @@
+package decimal
+
+import (
+	"fmt"
+	"io"
+)
+
+// Scanner adapts a *Decimal to fmt.Scanner. *Decimal cannot implement
+// fmt.Scanner itself because its Scan method implements sql.Scanner, so wrap
+// the targets instead:
+//
+//	var a, b decimal.Decimal
+//	fmt.Sscan("12.5 3.1", decimal.ScanInto(&a), decimal.ScanInto(&b))
+type Scanner struct {
+	d      *Decimal
+	parser *Parser
+}
+
+// ScanInto returns a Scanner that parses into d with the DefaultParser at the
+// time of the scan
+func ScanInto(d *Decimal) *Scanner {
+	return &Scanner{d: d}
+}
+
+// ScanInto returns a Scanner that parses into d with p
+func (p Parser) ScanInto(d *Decimal) *Scanner {
+	return &Scanner{d: d, parser: &p}
+}
+
+// Scan implements fmt.Scanner for the verbs %v, %s, %f, %F, %e, %E, %g and
+// %G, which all accept the same syntax. It reads the longest run of signs,
+// digits, points and letters, at most the width if one is given, and parses
+// it with the parser of s. It stops reading after MaxLength bytes of the
+// parser and fails, so long input is not buffered. d is only modified if the
+// parse succeeds.
+func (s *Scanner) Scan(state fmt.ScanState, verb rune) error {
+	switch verb {
+	case 'v', 's', 'f', 'F', 'e', 'E', 'g', 'G':
+	default:
+		return fmt.Errorf("Bad verb '%%%c' for Decimal", verb)
+	}
+	p := DefaultParser()
+	if s.parser != nil {
+		p = *s.parser
+	}
+	limit := p.Options().MaxLength
+	state.SkipSpace()
+	width, hasWidth := state.Width()
+	var buf [64]byte
+	token := buf[:0]
+	eof := false
+	for n := 0; !hasWidth || n < width; n++ {
+		r, _, err := state.ReadRune()
+		if err == io.EOF {
+			eof = true
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if !isNumberRune(r) {
+			if err := state.UnreadRune(); err != nil {
+				return err
+			}
+			break
+		}
+		token = append(token, byte(r))
+		if limit > 0 && len(token) > limit {
+			// stop reading unbounded input, the parser reports the length
+			break
+		}
+	}
+	switch {
+	case len(token) == 0 && eof:
+		return io.ErrUnexpectedEOF
+	case len(token) == 0:
+		return errInvalid
+	}
+
+	d, err := p.ParseBytes(token)
+	if err != nil {
+		return err
+	}
+	s.d.nat = d.nat
+	return nil
+}
+
+// isNumberRune reports whether r may be part of a decimal such as "-1.5E+3"
+// or "Infinity"
+func isNumberRune(r rune) bool {
+	return r >= '0' && r <= '9' || r == '+' || r == '-' || r == '.' ||
+		r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z'
+}
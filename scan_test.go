@@ -0,0 +1,97 @@
+package decimal_test
+
+import (
+	"database/sql"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/talon-one/decimal"
+)
+
+var _ fmt.Scanner = decimal.ScanInto(nil)
+var _ sql.Scanner = (*decimal.Decimal)(nil)
+
+func TestScanInto(t *testing.T) {
+	var a, b decimal.Decimal
+	n, err := fmt.Sscan("12.5 3.1", decimal.ScanInto(&a), decimal.ScanInto(&b))
+	require.NoError(t, err)
+	require.Equal(t, 2, n)
+	require.Equal(t, "12.5", a.String())
+	require.Equal(t, "3.1", b.String())
+
+	var c decimal.Decimal
+	var s string
+	n, err = fmt.Fscan(strings.NewReader("  -1.50E+3\n-Infinity"), decimal.ScanInto(&a), decimal.ScanInto(&c))
+	require.NoError(t, err)
+	require.Equal(t, 2, n)
+	require.Equal(t, "-1500", a.String())
+	require.Equal(t, "-Infinity", c.String())
+
+	n, err = fmt.Sscanf("price: 9.99 EUR", "price: %f %s", decimal.ScanInto(&a), &s)
+	require.NoError(t, err)
+	require.Equal(t, 2, n)
+	require.Equal(t, "9.99", a.String())
+	require.Equal(t, "EUR", s)
+
+	n, err = fmt.Sscanf("1.5,2.25", "%g,%e", decimal.ScanInto(&a), decimal.ScanInto(&b))
+	require.NoError(t, err)
+	require.Equal(t, 2, n)
+	require.Equal(t, "1.5", a.String())
+	require.Equal(t, "2.25", b.String())
+
+	n, err = fmt.Sscanf("12345", "%3v%v", decimal.ScanInto(&a), decimal.ScanInto(&b))
+	require.NoError(t, err)
+	require.Equal(t, 2, n)
+	require.Equal(t, "123", a.String())
+	require.Equal(t, "45", b.String())
+}
+
+func TestScanIntoErrors(t *testing.T) {
+	a := decimal.New(7, 0)
+	testData := []struct {
+		format string
+		input  string
+		err    string
+	}{
+		{format: "%v", input: "abc", err: "Invalid decimal"},
+		{format: "%v", input: "1.2.3", err: "Invalid decimal"},
+		{format: "%v", input: "NaN", err: "Invalid decimal"},
+		{format: "%v", input: ",5", err: "Invalid decimal"},
+		{format: "%v", input: "", err: io.ErrUnexpectedEOF.Error()},
+		{format: "%d", input: "5", err: "Bad verb '%d' for Decimal"},
+	}
+	for i, j := range testData {
+		_, err := fmt.Sscanf(j.input, j.format, decimal.ScanInto(&a))
+		require.EqualError(t, err, j.err, "At %d", i)
+		require.Equal(t, "7", a.String(), "At %d", i)
+	}
+}
+
+func TestParserScanInto(t *testing.T) {
+	var a decimal.Decimal
+	strict := decimal.NewParser(decimal.ParseOptions{MaxScale: 2})
+	_, err := fmt.Sscan("1.5E+3", strict.ScanInto(&a))
+	require.EqualError(t, err, "Invalid decimal `1.5E+3': exponent notation not allowed")
+	_, err = fmt.Sscan("1.505", strict.ScanInto(&a))
+	require.EqualError(t, err, "Invalid decimal `1.505': scale 3 exceeds 2")
+
+	_, err = fmt.Sscan("1.50", strict.ScanInto(&a))
+	require.NoError(t, err)
+	require.Equal(t, "1.50", a.String())
+
+	_, err = fmt.Sscan("+1", decimal.NewParser(decimal.ParseOptions{}).ScanInto(&a))
+	require.EqualError(t, err, "Invalid decimal `+1': leading '+' not allowed")
+
+	short := decimal.NewParser(decimal.ParseOptions{MaxLength: 5})
+	r := strings.NewReader("1234567890 5")
+	_, err = fmt.Fscan(r, short.ScanInto(&a))
+	require.EqualError(t, err, "Invalid decimal: length 6 exceeds 5")
+	require.Equal(t, 6, int(r.Size())-r.Len(), "read beyond the limit")
+	require.Equal(t, "1.50", a.String())
+
+	_, err = fmt.Sscan(strings.Repeat("9", 2000), decimal.ScanInto(&a))
+	require.EqualError(t, err, "Invalid decimal: length 1025 exceeds 1024")
+}
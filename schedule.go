@@ -0,0 +1,143 @@
+package decimal
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Schedule is a list of brackets with a rate each, as used for volume
+// discounts, progressive tax brackets or loyalty tier multipliers.
+// Bracket i covers [breakpoint i, breakpoint i+1), the last bracket is unbounded.
+type Schedule struct {
+	brackets []Range
+	rates    []Decimal
+}
+
+// BracketAmount is the share of a schedule evaluation that falls into a single bracket
+type BracketAmount struct {
+	// Range is the bracket the amount belongs to
+	Range Range
+	// Rate is the rate of the bracket
+	Rate Decimal
+	// Base is the part of the input the rate was applied to
+	Base Decimal
+	// Amount is Base multiplied by Rate
+	Amount Decimal
+}
+
+// ScheduleResult is the outcome of a schedule evaluation including a per-bracket breakdown
+type ScheduleResult struct {
+	// Brackets lists every bracket that contributed to Total, in ascending order
+	Brackets []BracketAmount
+	// Total is the sum of all bracket amounts
+	Total Decimal
+}
+
+// NewSchedule creates a schedule from the lower breakpoint of every bracket and
+// the rate that applies to it. Breakpoints must be strictly increasing and there
+// must be exactly one rate per breakpoint.
+func NewSchedule(breakpoints []Decimal, rates []Decimal) (Schedule, error) {
+	if len(breakpoints) == 0 {
+		return Schedule{}, errors.New("Schedule needs at least one breakpoint")
+	}
+	if len(breakpoints) != len(rates) {
+		return Schedule{}, fmt.Errorf("Schedule has %d breakpoints but %d rates", len(breakpoints), len(rates))
+	}
+	s := Schedule{
+		brackets: make([]Range, len(breakpoints)),
+		rates:    make([]Decimal, len(rates)),
+	}
+	for i := range breakpoints {
+		if breakpoints[i].IsNaN() || breakpoints[i].native().IsInf(0) {
+			return Schedule{}, fmt.Errorf("Schedule breakpoint %d is not finite", i)
+		}
+		upper := Unbounded()
+		if i+1 < len(breakpoints) {
+			if breakpoints[i+1].Cmp(breakpoints[i]) <= 0 {
+				return Schedule{}, fmt.Errorf("Schedule breakpoints must be strictly increasing: %s follows %s", breakpoints[i+1], breakpoints[i])
+			}
+			upper = Exclusive(breakpoints[i+1])
+		}
+		s.brackets[i] = NewRange(Inclusive(breakpoints[i]), upper)
+		s.rates[i] = NewFromDecimal(rates[i])
+	}
+	return s, nil
+}
+
+// MustNewSchedule is like NewSchedule but panics if the schedule is invalid
+func MustNewSchedule(breakpoints []Decimal, rates []Decimal) Schedule {
+	s, err := NewSchedule(breakpoints, rates)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// Brackets returns the ranges covered by the schedule, in ascending order
+func (s Schedule) Brackets() []Range {
+	brackets := make([]Range, len(s.brackets))
+	copy(brackets, s.brackets)
+	return brackets
+}
+
+// RateFor returns the rate of the bracket x lands in. It returns false if x is
+// below the first breakpoint.
+func (s Schedule) RateFor(x Decimal) (Decimal, bool) {
+	i := s.bracketFor(x)
+	if i < 0 {
+		return Zero(), false
+	}
+	return NewFromDecimal(s.rates[i]), true
+}
+
+func (s Schedule) bracketFor(x Decimal) int {
+	for i := len(s.brackets) - 1; i >= 0; i-- {
+		if s.brackets[i].Contains(x) {
+			return i
+		}
+	}
+	return -1
+}
+
+// Progressive applies every bracket's rate to the part of x that falls into
+// that bracket (marginal rates). Parts of x below the first breakpoint are not
+// charged. Amounts are not rounded to any scale. x will not be modified.
+func (s Schedule) Progressive(x Decimal) ScheduleResult {
+	result := ScheduleResult{Total: Zero()}
+	for i, bracket := range s.brackets {
+		lower := bracket.Lower().Value()
+		if x.Cmp(lower) <= 0 {
+			break
+		}
+		base := Sub(bracket.Clamp(x), lower)
+		amount := Mul(base, s.rates[i])
+		result.Brackets = append(result.Brackets, BracketAmount{
+			Range:  bracket,
+			Rate:   NewFromDecimal(s.rates[i]),
+			Base:   base,
+			Amount: amount,
+		})
+		result.Total.Add(amount)
+	}
+	return result
+}
+
+// Flat applies the rate of the bracket x lands in to the whole of x. If x is
+// below the first breakpoint the total is zero and the breakdown is empty.
+// Amounts are not rounded to any scale. x will not be modified.
+func (s Schedule) Flat(x Decimal) ScheduleResult {
+	i := s.bracketFor(x)
+	if i < 0 {
+		return ScheduleResult{Total: Zero()}
+	}
+	amount := Mul(x, s.rates[i])
+	return ScheduleResult{
+		Brackets: []BracketAmount{{
+			Range:  s.brackets[i],
+			Rate:   NewFromDecimal(s.rates[i]),
+			Base:   NewFromDecimal(x),
+			Amount: amount,
+		}},
+		Total: NewFromDecimal(amount),
+	}
+}
@@ -0,0 +1,98 @@
+package decimal_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/talon-one/decimal"
+)
+
+func decimals(values ...string) []decimal.Decimal {
+	return setup(values...).Decimals
+}
+
+func TestNewSchedule(t *testing.T) {
+	s, err := decimal.NewSchedule(decimals("0", "100", "500"), decimals("0", "0.05", "0.1"))
+	require.NoError(t, err)
+	brackets := s.Brackets()
+	require.Len(t, brackets, 3)
+	require.Equal(t, "[0, 100)", brackets[0].String())
+	require.Equal(t, "[100, 500)", brackets[1].String())
+	require.Equal(t, "[500, +inf)", brackets[2].String())
+
+	_, err = decimal.NewSchedule(nil, nil)
+	require.EqualError(t, err, "Schedule needs at least one breakpoint")
+
+	_, err = decimal.NewSchedule(decimals("0", "100"), decimals("0.1"))
+	require.EqualError(t, err, "Schedule has 2 breakpoints but 1 rates")
+
+	_, err = decimal.NewSchedule(decimals("0", "100", "100"), decimals("0", "0.1", "0.2"))
+	require.EqualError(t, err, "Schedule breakpoints must be strictly increasing: 100 follows 100")
+
+	_, err = decimal.NewSchedule(decimals("100", "50"), decimals("0", "0.1"))
+	require.EqualError(t, err, "Schedule breakpoints must be strictly increasing: 50 follows 100")
+}
+
+func TestScheduleProgressive(t *testing.T) {
+	s := decimal.MustNewSchedule(decimals("0", "10000", "50000"), decimals("0", "0.2", "0.4"))
+
+	testData := []struct {
+		input   string
+		total   string
+		amounts []string
+	}{
+		{input: "-5", total: "0"},
+		{input: "0", total: "0"},
+		{input: "8000", total: "0", amounts: []string{"0"}},
+		{input: "10000", total: "0", amounts: []string{"0"}},
+		{input: "30000", total: "4000.0", amounts: []string{"0", "4000.0"}},
+		{input: "60000.50", total: "12000.200", amounts: []string{"0", "8000.0", "4000.200"}},
+	}
+	for i, j := range testData {
+		data := setup(j.input)
+		result := s.Progressive(data.Decimals[0])
+		require.Equal(t, j.total, result.Total.String(), "At %d", i)
+		require.Len(t, result.Brackets, len(j.amounts), "At %d", i)
+		sum := decimal.Zero()
+		for k, amount := range j.amounts {
+			require.Equal(t, amount, result.Brackets[k].Amount.String(), "At %d, bracket %d", i, k)
+			sum = decimal.Add(sum, result.Brackets[k].Base)
+		}
+		if len(j.amounts) > 0 {
+			require.True(t, sum.Equals(data.Decimals[0]), "At %d: bases sum up to %s", i, sum)
+		}
+		data.VerifyIntegrity(t)
+	}
+}
+
+func TestScheduleFlat(t *testing.T) {
+	s := decimal.MustNewSchedule(decimals("50", "100", "500"), decimals("0.05", "0.1", "0.15"))
+
+	testData := []struct {
+		input string
+		total string
+		rate  string
+	}{
+		{input: "49.99", total: "0"},
+		{input: "50", total: "2.50", rate: "0.05"},
+		{input: "99.99", total: "4.9995", rate: "0.05"},
+		{input: "100", total: "10.0", rate: "0.1"},
+		{input: "1000", total: "150.00", rate: "0.15"},
+	}
+	for i, j := range testData {
+		data := setup(j.input)
+		result := s.Flat(data.Decimals[0])
+		require.Equal(t, j.total, result.Total.String(), "At %d", i)
+		rate, ok := s.RateFor(data.Decimals[0])
+		if j.rate == "" {
+			require.False(t, ok, "At %d", i)
+			require.Empty(t, result.Brackets, "At %d", i)
+		} else {
+			require.True(t, ok, "At %d", i)
+			require.Equal(t, j.rate, rate.String(), "At %d", i)
+			require.Len(t, result.Brackets, 1, "At %d", i)
+			require.Equal(t, j.input, result.Brackets[0].Base.String(), "At %d", i)
+		}
+		data.VerifyIntegrity(t)
+	}
+}
@@ -9,9 +9,20 @@ func (d Decimal) Value() (driver.Value, error) {
 	return d.String(), nil
 }
 
-// Scan implements the sql.Scanner interface for database deserialization
+// Scan implements the sql.Scanner interface for database deserialization,
+// strings and byte slices are parsed with the DefaultParser. For fmt.Sscan and
+// friends use ScanInto.
 func (d *Decimal) Scan(value interface{}) error {
-	dec, err := NewFromInterface(value)
+	var dec Decimal
+	var err error
+	switch v := value.(type) {
+	case string:
+		dec, err = DefaultParser().Parse(v)
+	case []byte:
+		dec, err = DefaultParser().ParseBytes(v)
+	default:
+		dec, err = NewFromInterface(value)
+	}
 	if err != nil {
 		return err
 	}
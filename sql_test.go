@@ -54,3 +54,11 @@ func TestSQLScan(t *testing.T) {
 		}
 	})
 }
+
+func TestScanBytes(t *testing.T) {
+	var d Decimal
+	require.NoError(t, d.Scan([]byte("1.50")))
+	require.Equal(t, "1.50", d.String())
+	require.Error(t, d.Scan([]byte("AB")))
+	require.Equal(t, "1.50", d.String())
+}
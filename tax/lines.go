@@ -0,0 +1,155 @@
+package tax
+
+import (
+	"math/big"
+
+	"github.com/talon-one/decimal"
+)
+
+// Rounding determines where the taxes of an invoice are rounded
+type Rounding int
+
+const (
+	// PerLine rounds the taxes of every line, the total is the sum of the lines
+	PerLine Rounding = iota
+	// PerTotal rounds the taxes of the invoice total once and splits them
+	// across the lines in proportion to their exact taxes
+	PerTotal
+)
+
+func (r Rounding) String() string {
+	if r == PerTotal {
+		return "PerTotal"
+	}
+	return "PerLine"
+}
+
+// Invoice is the result of taxing several lines. The amounts of the lines add
+// up to the amounts of Total, including the taxes of every rate.
+type Invoice struct {
+	Lines []Result
+	Total Result
+}
+
+// AddTaxToLines returns the taxes on the net amounts of the lines with the
+// rounding policy. See AddTax for the rounding of a single amount and the errors.
+func AddTaxToLines(nets []decimal.Decimal, rounding Rounding, scale int, mode decimal.RoundingMode, rates ...Rate) (Invoice, error) {
+	if rounding == PerLine {
+		lines := make([]Result, len(nets))
+		for i, net := range nets {
+			line, err := AddTax(net, scale, mode, rates...)
+			if err != nil {
+				return Invoice{}, err
+			}
+			lines[i] = line
+		}
+		return sumLines(lines, rates, scale), nil
+	}
+
+	exactNets := make([]*big.Rat, len(nets))
+	sum := new(big.Rat)
+	for i, net := range nets {
+		exact, err := toRat(net, "net amount")
+		if err != nil {
+			return Invoice{}, err
+		}
+		exactNets[i] = roundRat(exact, scale, mode)
+		sum.Add(sum, exactNets[i])
+	}
+	total, err := AddTax(toDecimal(sum, scale), scale, mode, rates...)
+	if err != nil {
+		return Invoice{}, err
+	}
+	invoice := Invoice{Lines: make([]Result, len(nets)), Total: total}
+	for j, taxes := range splitTaxes(invoice.Total, exactNets, rates, scale) {
+		invoice.Lines[j] = newResult(exactNets[j], taxes, rates, scale)
+	}
+	return invoice, nil
+}
+
+// ExtractTaxFromLines returns the taxes contained in the gross amounts of the
+// lines with the rounding policy. See ExtractTax for the rounding of a single
+// amount and the errors.
+func ExtractTaxFromLines(grosses []decimal.Decimal, rounding Rounding, scale int, mode decimal.RoundingMode, rates ...Rate) (Invoice, error) {
+	if rounding == PerLine {
+		lines := make([]Result, len(grosses))
+		for i, gross := range grosses {
+			line, err := ExtractTax(gross, scale, mode, rates...)
+			if err != nil {
+				return Invoice{}, err
+			}
+			lines[i] = line
+		}
+		return sumLines(lines, rates, scale), nil
+	}
+	if err := checkRates(rates); err != nil {
+		return Invoice{}, err
+	}
+
+	f := factor(rates)
+	exactGrosses := make([]*big.Rat, len(grosses))
+	exactNets := make([]*big.Rat, len(grosses))
+	sum := new(big.Rat)
+	for i, gross := range grosses {
+		exact, err := toRat(gross, "gross amount")
+		if err != nil {
+			return Invoice{}, err
+		}
+		exactGrosses[i] = roundRat(exact, scale, mode)
+		exactNets[i] = new(big.Rat).Quo(exactGrosses[i], f)
+		sum.Add(sum, exactGrosses[i])
+	}
+	total, err := ExtractTax(toDecimal(sum, scale), scale, mode, rates...)
+	if err != nil {
+		return Invoice{}, err
+	}
+	invoice := Invoice{Lines: make([]Result, len(grosses)), Total: total}
+	for j, taxes := range splitTaxes(invoice.Total, exactNets, rates, scale) {
+		// the gross amount of the line is given, the net amount is the rest
+		net := new(big.Rat).Set(exactGrosses[j])
+		for _, t := range taxes {
+			net.Sub(net, t)
+		}
+		invoice.Lines[j] = newResult(net, taxes, rates, scale)
+	}
+	return invoice, nil
+}
+
+// splitTaxes allocates the taxes of total across the lines with the exact net
+// amounts nets, it returns the taxes of every line by rate.
+func splitTaxes(total Result, nets []*big.Rat, rates []Rate, scale int) [][]*big.Rat {
+	weights := make([][]*big.Rat, len(rates))
+	for i := range weights {
+		weights[i] = make([]*big.Rat, len(nets))
+	}
+	for j, net := range nets {
+		for i, t := range exactTaxes(net, rates) {
+			weights[i][j] = t
+		}
+	}
+	taxes := make([][]*big.Rat, len(nets))
+	for j := range taxes {
+		taxes[j] = make([]*big.Rat, len(rates))
+	}
+	for i, amount := range total.Taxes {
+		for j, share := range allocate(amount.Tax.MustRat(), weights[i], scale) {
+			taxes[j][i] = share
+		}
+	}
+	return taxes
+}
+
+func sumLines(lines []Result, rates []Rate, scale int) Invoice {
+	net := new(big.Rat)
+	taxes := make([]*big.Rat, len(rates))
+	for i := range taxes {
+		taxes[i] = new(big.Rat)
+	}
+	for _, line := range lines {
+		net.Add(net, line.Net.MustRat())
+		for i, t := range line.Taxes {
+			taxes[i].Add(taxes[i], t.Tax.MustRat())
+		}
+	}
+	return Invoice{Lines: lines, Total: newResult(net, taxes, rates, scale)}
+}
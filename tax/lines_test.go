@@ -0,0 +1,101 @@
+package tax_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/talon-one/decimal"
+	"github.com/talon-one/decimal/tax"
+)
+
+// verifyInvoice checks that the lines of invoice add up to its total
+func verifyInvoice(t *testing.T, invoice tax.Invoice) {
+	verify(t, invoice.Total)
+	net, gross := decimal.Zero(), decimal.Zero()
+	rates := make([]decimal.Decimal, len(invoice.Total.Taxes))
+	for i := range rates {
+		rates[i] = decimal.Zero()
+	}
+	for _, line := range invoice.Lines {
+		verify(t, line)
+		net = decimal.Add(net, line.Net)
+		gross = decimal.Add(gross, line.Gross)
+		for i, a := range line.Taxes {
+			rates[i] = decimal.Add(rates[i], a.Tax)
+		}
+	}
+	require.True(t, net.Equals(invoice.Total.Net), "%s ≠ %s", net, invoice.Total.Net)
+	require.True(t, gross.Equals(invoice.Total.Gross), "%s ≠ %s", gross, invoice.Total.Gross)
+	for i, a := range invoice.Total.Taxes {
+		require.True(t, rates[i].Equals(a.Tax), "%s ≠ %s", rates[i], a.Tax)
+	}
+}
+
+func TestAddTaxToLines(t *testing.T) {
+	nets := []decimal.Decimal{d("0.02"), d("0.02"), d("0.02"), d("0.02"), d("0.02")}
+
+	perLine, err := tax.AddTaxToLines(nets, tax.PerLine, 2, decimal.ToNearestEven, vat)
+	require.NoError(t, err)
+	require.Equal(t, "0", perLine.Total.Tax.String())
+	require.Equal(t, "0.10", perLine.Total.Gross.String())
+	verifyInvoice(t, perLine)
+
+	perTotal, err := tax.AddTaxToLines(nets, tax.PerTotal, 2, decimal.ToNearestEven, vat)
+	require.NoError(t, err)
+	require.Equal(t, "0.02", perTotal.Total.Tax.String())
+	require.Equal(t, "0.12", perTotal.Total.Gross.String())
+	require.Equal(t, "0.01", perTotal.Lines[0].Tax.String())
+	require.Equal(t, "0.01", perTotal.Lines[1].Tax.String())
+	require.Equal(t, "0", perTotal.Lines[2].Tax.String())
+	verifyInvoice(t, perTotal)
+}
+
+func TestExtractTaxFromLines(t *testing.T) {
+	grosses := []decimal.Decimal{d("0.99"), d("0.99"), d("0.99")}
+
+	perLine, err := tax.ExtractTaxFromLines(grosses, tax.PerLine, 2, decimal.ToNearestEven, vat)
+	require.NoError(t, err)
+	require.Equal(t, "2.49", perLine.Total.Net.String())
+	require.Equal(t, "0.48", perLine.Total.Tax.String())
+	verifyInvoice(t, perLine)
+
+	perTotal, err := tax.ExtractTaxFromLines(grosses, tax.PerTotal, 2, decimal.ToNearestEven, vat)
+	require.NoError(t, err)
+	require.Equal(t, "2.50", perTotal.Total.Net.String())
+	require.Equal(t, "0.47", perTotal.Total.Tax.String())
+	for _, line := range perTotal.Lines {
+		require.Equal(t, "0.99", line.Gross.String())
+	}
+	verifyInvoice(t, perTotal)
+}
+
+func TestLinesReconcile(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	rateSets := [][]tax.Rate{{vat}, {gst, pst}, {gst, qst}}
+	for k := 0; k < 200; k++ {
+		amounts := make([]decimal.Decimal, 1+rnd.Intn(8))
+		for i := range amounts {
+			amounts[i] = decimal.New(rnd.Int63n(200000)-20000, int32(rnd.Intn(4)))
+		}
+		rates := rateSets[k%len(rateSets)]
+		for _, rounding := range []tax.Rounding{tax.PerLine, tax.PerTotal} {
+			added, err := tax.AddTaxToLines(amounts, rounding, 2, decimal.ToNearestEven, rates...)
+			require.NoError(t, err, "At %d", k)
+			verifyInvoice(t, added)
+			extracted, err := tax.ExtractTaxFromLines(amounts, rounding, 2, decimal.ToNearestEven, rates...)
+			require.NoError(t, err, "At %d", k)
+			verifyInvoice(t, extracted)
+		}
+	}
+}
+
+func TestLinesNotFinite(t *testing.T) {
+	lines := []decimal.Decimal{d("1"), d("Infinity")}
+	for _, rounding := range []tax.Rounding{tax.PerLine, tax.PerTotal} {
+		_, err := tax.AddTaxToLines(lines, rounding, 2, decimal.ToNearestEven, vat)
+		require.EqualError(t, err, "Invalid net amount `Infinity'", "At %s", rounding)
+		_, err = tax.ExtractTaxFromLines(lines, rounding, 2, decimal.ToNearestEven, vat)
+		require.EqualError(t, err, "Invalid gross amount `Infinity'", "At %s", rounding)
+	}
+}
@@ -0,0 +1,183 @@
+// Package tax calculates taxes such as VAT on decimal amounts.
+//
+// Rates are ratios, a VAT of 19% is a rate of 0.19. All amounts are computed
+// exactly and rounded to a scale with a rounding mode only where stated, the
+// net and tax amounts of a Result always add up to its gross amount.
+package tax
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/talon-one/decimal"
+)
+
+// Rate is a tax rate applied to a net amount
+type Rate struct {
+	// Name identifies the tax, such as "VAT"
+	Name string
+	// Rate is the ratio of the tax, such as 0.19 for 19%
+	Rate decimal.Decimal
+	// Compound is set if the tax is levied on the net amount plus the taxes of
+	// the preceding rates instead of on the net amount only.
+	Compound bool
+}
+
+// NewRate returns a rate that is applied to the net amount
+func NewRate(name string, rate decimal.Decimal) Rate {
+	return Rate{Name: name, Rate: decimal.NewFromDecimal(rate)}
+}
+
+// NewCompoundRate returns a rate that is applied to the net amount plus the
+// taxes of the preceding rates, a tax on tax.
+func NewCompoundRate(name string, rate decimal.Decimal) Rate {
+	return Rate{Name: name, Rate: decimal.NewFromDecimal(rate), Compound: true}
+}
+
+// Amount is the tax of a single rate
+type Amount struct {
+	Rate Rate
+	Tax  decimal.Decimal
+}
+
+// Result is a net amount with its taxes, Net + Tax == Gross holds exactly
+// and Tax is the sum of the amounts in Taxes.
+type Result struct {
+	Net   decimal.Decimal
+	Tax   decimal.Decimal
+	Gross decimal.Decimal
+	// Taxes lists the tax of every rate in the order of the rates
+	Taxes []Amount
+}
+
+// AddTax returns the taxes on the net amount. net is rounded to scale with
+// mode, the tax of every rate is computed exactly and rounded to scale with
+// mode, the gross amount is their sum. It fails if net or a rate is NaN or
+// Infinity.
+func AddTax(net decimal.Decimal, scale int, mode decimal.RoundingMode, rates ...Rate) (Result, error) {
+	exact, err := toRat(net, "net amount")
+	if err != nil {
+		return Result{}, err
+	}
+	if err := checkRates(rates); err != nil {
+		return Result{}, err
+	}
+	n := roundRat(exact, scale, mode)
+	taxes := exactTaxes(n, rates)
+	rounded := make([]*big.Rat, len(taxes))
+	for i, t := range taxes {
+		rounded[i] = roundRat(t, scale, mode)
+	}
+	return newResult(n, rounded, rates, scale), nil
+}
+
+// ExtractTax returns the taxes contained in the gross amount. gross is rounded
+// to scale with mode, the net amount is the exact net amount rounded to scale
+// with mode and the tax is the difference. The tax is split across the rates in
+// proportion to their exact amounts with decimal.Allocate. It fails if gross
+// or a rate is NaN or Infinity.
+func ExtractTax(gross decimal.Decimal, scale int, mode decimal.RoundingMode, rates ...Rate) (Result, error) {
+	exact, err := toRat(gross, "gross amount")
+	if err != nil {
+		return Result{}, err
+	}
+	if err := checkRates(rates); err != nil {
+		return Result{}, err
+	}
+	g := roundRat(exact, scale, mode)
+	exactNet := new(big.Rat).Quo(g, factor(rates))
+	n := roundRat(exactNet, scale, mode)
+	tax := new(big.Rat).Sub(g, n)
+	return newResult(n, allocate(tax, exactTaxes(exactNet, rates), scale), rates, scale), nil
+}
+
+func newResult(net *big.Rat, taxes []*big.Rat, rates []Rate, scale int) Result {
+	total := new(big.Rat)
+	r := Result{Taxes: make([]Amount, len(rates))}
+	for i, t := range taxes {
+		total.Add(total, t)
+		r.Taxes[i] = Amount{Rate: rates[i], Tax: toDecimal(t, scale)}
+	}
+	r.Net = toDecimal(net, scale)
+	r.Tax = toDecimal(total, scale)
+	r.Gross = toDecimal(total.Add(total, net), scale)
+	return r
+}
+
+// checkRates fails if a rate is not finite, the rates can be converted with
+// MustRat afterwards
+func checkRates(rates []Rate) error {
+	for _, rate := range rates {
+		if _, err := rate.Rate.Rat(); err != nil {
+			return fmt.Errorf("Invalid %s rate `%s'", rate.Name, rate.Rate)
+		}
+	}
+	return nil
+}
+
+// exactTaxes returns the unrounded tax of every rate on net
+func exactTaxes(net *big.Rat, rates []Rate) []*big.Rat {
+	taxes := make([]*big.Rat, len(rates))
+	base := new(big.Rat).Set(net)
+	for i, rate := range rates {
+		if rate.Compound {
+			taxes[i] = new(big.Rat).Mul(base, rate.Rate.MustRat())
+		} else {
+			taxes[i] = new(big.Rat).Mul(net, rate.Rate.MustRat())
+		}
+		base.Add(base, taxes[i])
+	}
+	return taxes
+}
+
+// factor returns the ratio of the gross to the net amount
+func factor(rates []Rate) *big.Rat {
+	f := big.NewRat(1, 1)
+	for _, t := range exactTaxes(big.NewRat(1, 1), rates) {
+		f.Add(f, t)
+	}
+	return f
+}
+
+// allocate splits total, which has at most scale digits, across the weights
+// in proportion with decimal.Allocate, the shares add up to total.
+func allocate(total *big.Rat, weights []*big.Rat, scale int) []*big.Rat {
+	// Allocate takes decimal weights, the exact weights are scaled to
+	// integers by their common denominator
+	den := big.NewInt(1)
+	for _, w := range weights {
+		den.Mul(den, new(big.Int).Quo(w.Denom(), new(big.Int).GCD(nil, nil, den, w.Denom())))
+	}
+	integers := make([]decimal.Decimal, len(weights))
+	for i, w := range weights {
+		n := new(big.Int).Mul(w.Num(), new(big.Int).Quo(den, w.Denom()))
+		integers[i] = decimal.MustNewFromString(n.String())
+	}
+	parts := decimal.Allocate(toDecimal(total, scale), integers, scale)
+	shares := make([]*big.Rat, len(parts))
+	for i, p := range parts {
+		shares[i] = p.MustRat()
+	}
+	return shares
+}
+
+// toRat converts the amount d exactly, what names it in the error for NaN and Infinity
+func toRat(d decimal.Decimal, what string) (*big.Rat, error) {
+	r, err := d.Rat()
+	if err != nil {
+		return nil, fmt.Errorf("Invalid %s `%s'", what, d)
+	}
+	return r, nil
+}
+
+// roundRat rounds r to scale with mode
+func roundRat(r *big.Rat, scale int, mode decimal.RoundingMode) *big.Rat {
+	num := decimal.MustNewFromString(r.Num().String())
+	den := decimal.MustNewFromString(r.Denom().String())
+	return decimal.DivMode(num, den, scale, mode).MustRat()
+}
+
+// toDecimal converts r, which must have at most scale digits, to a decimal with scale digits
+func toDecimal(r *big.Rat, scale int) decimal.Decimal {
+	return decimal.MustNewFromString(r.FloatString(scale))
+}
@@ -0,0 +1,121 @@
+package tax_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/talon-one/decimal"
+	"github.com/talon-one/decimal/tax"
+)
+
+func d(s string) decimal.Decimal {
+	return decimal.MustNewFromString(s)
+}
+
+func taxes(r tax.Result) []string {
+	var list []string
+	for _, t := range r.Taxes {
+		list = append(list, t.Rate.Name+" "+t.Tax.String())
+	}
+	return list
+}
+
+// verify checks that the amounts of r reconcile exactly
+func verify(t *testing.T, r tax.Result) {
+	require.True(t, decimal.Add(r.Net, r.Tax).Equals(r.Gross), "%s + %s ≠ %s", r.Net, r.Tax, r.Gross)
+	sum := decimal.Zero()
+	for _, a := range r.Taxes {
+		sum = decimal.Add(sum, a.Tax)
+	}
+	require.True(t, sum.Equals(r.Tax), "%s ≠ %s", sum, r.Tax)
+}
+
+var (
+	vat = tax.NewRate("VAT", d("0.19"))
+	gst = tax.NewRate("GST", d("0.05"))
+	pst = tax.NewRate("PST", d("0.07"))
+	qst = tax.NewCompoundRate("QST", d("0.09975"))
+)
+
+func TestAddTax(t *testing.T) {
+	testData := []struct {
+		net   string
+		mode  decimal.RoundingMode
+		rates []tax.Rate
+		tax   string
+		gross string
+		taxes []string
+	}{
+		{net: "100", rates: []tax.Rate{vat}, tax: "19.00", gross: "119.00", taxes: []string{"VAT 19.00"}},
+		{net: "9.99", rates: []tax.Rate{vat}, tax: "1.90", gross: "11.89", taxes: []string{"VAT 1.90"}},
+		{net: "9.99", mode: decimal.ToZero, rates: []tax.Rate{vat}, tax: "1.89", gross: "11.88", taxes: []string{"VAT 1.89"}},
+		{net: "-9.99", mode: decimal.ToNearestAway, rates: []tax.Rate{vat}, tax: "-1.90", gross: "-11.89", taxes: []string{"VAT -1.90"}},
+		{net: "100", rates: []tax.Rate{gst, pst}, tax: "12.00", gross: "112.00", taxes: []string{"GST 5.00", "PST 7.00"}},
+		{net: "100", rates: []tax.Rate{gst, qst}, tax: "15.47", gross: "115.47", taxes: []string{"GST 5.00", "QST 10.47"}},
+		{net: "19.999", rates: []tax.Rate{vat}, tax: "3.80", gross: "23.80", taxes: []string{"VAT 3.80"}},
+		{net: "50", tax: "0", gross: "50.00"},
+	}
+	for i, j := range testData {
+		r, err := tax.AddTax(d(j.net), 2, j.mode, j.rates...)
+		require.NoError(t, err, "At %d", i)
+		require.Equal(t, j.tax, r.Tax.String(), "At %d", i)
+		require.Equal(t, j.gross, r.Gross.String(), "At %d", i)
+		require.Equal(t, j.taxes, taxes(r), "At %d", i)
+		verify(t, r)
+	}
+}
+
+func TestExtractTax(t *testing.T) {
+	testData := []struct {
+		gross string
+		rates []tax.Rate
+		net   string
+		tax   string
+		taxes []string
+	}{
+		{gross: "119", rates: []tax.Rate{vat}, net: "100.00", tax: "19.00", taxes: []string{"VAT 19.00"}},
+		{gross: "10", rates: []tax.Rate{vat}, net: "8.40", tax: "1.60", taxes: []string{"VAT 1.60"}},
+		{gross: "112", rates: []tax.Rate{gst, pst}, net: "100.00", tax: "12.00", taxes: []string{"GST 5.00", "PST 7.00"}},
+		{gross: "115.47", rates: []tax.Rate{gst, qst}, net: "100.00", tax: "15.47", taxes: []string{"GST 5.00", "QST 10.47"}},
+		{gross: "0.01", rates: []tax.Rate{gst, pst}, net: "0.01", tax: "0", taxes: []string{"GST 0", "PST 0"}},
+		{gross: "-10", rates: []tax.Rate{vat}, net: "-8.40", tax: "-1.60", taxes: []string{"VAT -1.60"}},
+	}
+	for i, j := range testData {
+		r, err := tax.ExtractTax(d(j.gross), 2, decimal.ToNearestEven, j.rates...)
+		require.NoError(t, err, "At %d", i)
+		require.Equal(t, j.net, r.Net.String(), "At %d", i)
+		require.Equal(t, j.tax, r.Tax.String(), "At %d", i)
+		require.Equal(t, j.taxes, taxes(r), "At %d", i)
+		verify(t, r)
+	}
+}
+
+func TestAddAndExtractRoundTrip(t *testing.T) {
+	for cents := 1; cents < 2000; cents += 7 {
+		net := decimal.New(int64(cents), 2)
+		added, err := tax.AddTax(net, 2, decimal.ToNearestEven, gst, qst)
+		require.NoError(t, err, "At %d", cents)
+		verify(t, added)
+		extracted, err := tax.ExtractTax(added.Gross, 2, decimal.ToNearestEven, gst, qst)
+		require.NoError(t, err, "At %d", cents)
+		verify(t, extracted)
+		require.True(t, extracted.Gross.Equals(added.Gross), "At %d", cents)
+	}
+}
+
+func TestTaxNotFinite(t *testing.T) {
+	inf := d("Infinity")
+	nan := decimal.Mul(inf, decimal.Zero())
+
+	_, err := tax.AddTax(d("-Infinity"), 2, decimal.ToNearestEven, vat)
+	require.EqualError(t, err, "Invalid net amount `-Infinity'")
+	_, err = tax.ExtractTax(inf, 2, decimal.ToNearestEven, vat)
+	require.EqualError(t, err, "Invalid gross amount `Infinity'")
+	_, err = tax.AddTax(d("100"), 2, decimal.ToNearestEven, gst, tax.NewRate("PST", inf))
+	require.EqualError(t, err, "Invalid PST rate `Infinity'")
+
+	_, err = tax.AddTax(nan, 2, decimal.ToNearestEven, vat)
+	require.Error(t, err)
+	_, err = tax.ExtractTax(d("100"), 2, decimal.ToNearestEven, tax.NewCompoundRate("QST", nan))
+	require.Error(t, err)
+}
@@ -0,0 +1,343 @@
+package words
+
+import "strings"
+
+// form is the grammatical context of a spelled out number, it selects between
+// forms of one such as "eins", "ein" and "eine"
+type form int
+
+const (
+	// standalone numbers are not followed by a noun
+	standalone form = iota
+	// masculine numbers precede a masculine or neuter noun
+	masculine
+	// feminine numbers precede a feminine noun
+	feminine
+)
+
+// speller spells out a non-negative integer given as groups of three digits,
+// the least significant group first
+type speller func(groups []int, f form) string
+
+func isZero(groups []int) bool {
+	for _, g := range groups {
+		if g != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// English uses the short scale and no "and" after hundreds, as on US cheques
+
+var (
+	enOnes = [...]string{"zero", "one", "two", "three", "four", "five", "six", "seven", "eight", "nine",
+		"ten", "eleven", "twelve", "thirteen", "fourteen", "fifteen", "sixteen", "seventeen", "eighteen", "nineteen"}
+	enTens   = [...]string{"", "", "twenty", "thirty", "forty", "fifty", "sixty", "seventy", "eighty", "ninety"}
+	enScales = [...]string{"", "thousand", "million", "billion", "trillion", "quadrillion", "quintillion",
+		"sextillion", "septillion", "octillion", "nonillion", "decillion", "undecillion"}
+)
+
+func english(groups []int, f form) string {
+	if isZero(groups) {
+		return enOnes[0]
+	}
+	var parts []string
+	for i := len(groups) - 1; i >= 0; i-- {
+		if groups[i] == 0 {
+			continue
+		}
+		parts = append(parts, englishBelow1000(groups[i]))
+		if i > 0 {
+			parts = append(parts, enScales[i])
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+func englishBelow1000(n int) string {
+	var parts []string
+	if h := n / 100; h > 0 {
+		parts = append(parts, enOnes[h], "hundred")
+	}
+	switch r := n % 100; {
+	case r == 0:
+	case r < 20:
+		parts = append(parts, enOnes[r])
+	case r%10 == 0:
+		parts = append(parts, enTens[r/10])
+	default:
+		parts = append(parts, enTens[r/10]+"-"+enOnes[r%10])
+	}
+	return strings.Join(parts, " ")
+}
+
+// German writes numbers below a million as one word, uses the long scale and
+// writes the leading one of hundreds and thousands, "eintausendeinhundert"
+
+var (
+	deOnes = [...]string{"null", "eins", "zwei", "drei", "vier", "fünf", "sechs", "sieben", "acht", "neun",
+		"zehn", "elf", "zwölf", "dreizehn", "vierzehn", "fünfzehn", "sechzehn", "siebzehn", "achtzehn", "neunzehn"}
+	deTens   = [...]string{"", "", "zwanzig", "dreißig", "vierzig", "fünfzig", "sechzig", "siebzig", "achtzig", "neunzig"}
+	deScales = [...][2]string{{}, {}, {"Million", "Millionen"}, {"Milliarde", "Milliarden"}, {"Billion", "Billionen"},
+		{"Billiarde", "Billiarden"}, {"Trillion", "Trillionen"}, {"Trilliarde", "Trilliarden"},
+		{"Quadrillion", "Quadrillionen"}, {"Quadrilliarde", "Quadrilliarden"}, {"Quintillion", "Quintillionen"},
+		{"Quintilliarde", "Quintilliarden"}, {"Sextillion", "Sextillionen"}}
+)
+
+func german(groups []int, f form) string {
+	if isZero(groups) {
+		return deOnes[0]
+	}
+	var parts []string
+	for i := len(groups) - 1; i >= 2; i-- {
+		switch groups[i] {
+		case 0:
+		case 1:
+			parts = append(parts, "eine "+deScales[i][0])
+		default:
+			parts = append(parts, germanBelow1000(groups[i], feminine)+" "+deScales[i][1])
+		}
+	}
+	low := groups[0]
+	if len(groups) > 1 {
+		low += 1000 * groups[1]
+	}
+	if low > 0 {
+		var s string
+		if t := low / 1000; t > 0 {
+			s = germanBelow1000(t, masculine) + "tausend"
+		}
+		if r := low % 1000; r > 0 {
+			s += germanBelow1000(r, f)
+		}
+		parts = append(parts, s)
+	}
+	return strings.Join(parts, " ")
+}
+
+func germanBelow1000(n int, f form) string {
+	var s string
+	if h := n / 100; h == 1 {
+		s = "einhundert"
+	} else if h > 1 {
+		s = deOnes[h] + "hundert"
+	}
+	switch r := n % 100; {
+	case r == 0:
+	case r == 1 && f == masculine:
+		s += "ein"
+	case r == 1 && f == feminine:
+		s += "eine"
+	case r < 20:
+		s += deOnes[r]
+	case r%10 == 0:
+		s += deTens[r/10]
+	case r%10 == 1:
+		s += "einund" + deTens[r/10]
+	default:
+		s += deOnes[r%10] + "und" + deTens[r/10]
+	}
+	return s
+}
+
+// French follows the traditional rules, "vingt et un", "quatre-vingts" and
+// "deux cents" but "deux cent mille", with the long scale
+
+var (
+	frOnes = [...]string{"zéro", "un", "deux", "trois", "quatre", "cinq", "six", "sept", "huit", "neuf",
+		"dix", "onze", "douze", "treize", "quatorze", "quinze", "seize", "dix-sept", "dix-huit", "dix-neuf"}
+	frTens   = [...]string{"", "", "vingt", "trente", "quarante", "cinquante", "soixante"}
+	frScales = [...]string{"", "", "million", "milliard", "billion", "billiard", "trillion", "trilliard",
+		"quadrillion", "quadrilliard", "quintillion", "quintilliard", "sextillion"}
+)
+
+func french(groups []int, f form) string {
+	if isZero(groups) {
+		return frOnes[0]
+	}
+	var parts []string
+	for i := len(groups) - 1; i >= 2; i-- {
+		switch groups[i] {
+		case 0:
+		case 1:
+			parts = append(parts, "un "+frScales[i])
+		default:
+			parts = append(parts, frenchBelow1000(groups[i], masculine, true)+" "+frScales[i]+"s")
+		}
+	}
+	if len(groups) > 1 {
+		switch groups[1] {
+		case 0:
+		case 1:
+			parts = append(parts, "mille")
+		default:
+			// mille is invariable and does not pluralize the preceding cent or vingt
+			parts = append(parts, frenchBelow1000(groups[1], masculine, false)+" mille")
+		}
+	}
+	if groups[0] > 0 {
+		parts = append(parts, frenchBelow1000(groups[0], f, true))
+	}
+	return strings.Join(parts, " ")
+}
+
+// frenchBelow1000 spells out n, final is set if nothing but a noun follows
+// and cent and quatre-vingt take their plural s
+func frenchBelow1000(n int, f form, final bool) string {
+	h, r := n/100, n%100
+	if h == 0 {
+		return frenchBelow100(r, f, final)
+	}
+	s := "cent"
+	if h > 1 {
+		s = frOnes[h] + " cent"
+	}
+	if r == 0 {
+		if h > 1 && final {
+			s += "s"
+		}
+		return s
+	}
+	return s + " " + frenchBelow100(r, f, final)
+}
+
+func frenchBelow100(n int, f form, final bool) string {
+	one := "un"
+	if f == feminine {
+		one = "une"
+	}
+	t, u := n/10, n%10
+	switch {
+	case n == 1:
+		return one
+	case n < 20:
+		return frOnes[n]
+	case t <= 6 && u == 0:
+		return frTens[t]
+	case t <= 6 && u == 1:
+		return frTens[t] + " et " + one
+	case t <= 6:
+		return frTens[t] + "-" + frOnes[u]
+	case n == 71:
+		return "soixante et onze"
+	case t == 7:
+		return "soixante-" + frOnes[10+u]
+	case n == 80 && final:
+		return "quatre-vingts"
+	case n == 80:
+		return "quatre-vingt"
+	case n == 81:
+		return "quatre-vingt-" + one
+	case t == 8:
+		return "quatre-vingt-" + frOnes[u]
+	}
+	return "quatre-vingt-" + frOnes[10+u]
+}
+
+// Spanish uses the long scale in steps of a million, "mil millones" for 10^9,
+// and shortens uno before nouns, "veintiún euros"
+
+var (
+	esOnes = [...]string{"cero", "uno", "dos", "tres", "cuatro", "cinco", "seis", "siete", "ocho", "nueve",
+		"diez", "once", "doce", "trece", "catorce", "quince", "dieciséis", "diecisiete", "dieciocho", "diecinueve",
+		"veinte", "veintiuno", "veintidós", "veintitrés", "veinticuatro", "veinticinco", "veintiséis", "veintisiete",
+		"veintiocho", "veintinueve"}
+	esTens     = [...]string{"", "", "", "treinta", "cuarenta", "cincuenta", "sesenta", "setenta", "ochenta", "noventa"}
+	esHundreds = [...]string{"", "ciento", "doscientos", "trescientos", "cuatrocientos", "quinientos", "seiscientos",
+		"setecientos", "ochocientos", "novecientos"}
+	esScales = [...][2]string{{}, {"millón", "millones"}, {"billón", "billones"}, {"trillón", "trillones"},
+		{"cuatrillón", "cuatrillones"}, {"quintillón", "quintillones"}, {"sextillón", "sextillones"}}
+)
+
+func spanish(groups []int, f form) string {
+	if isZero(groups) {
+		return esOnes[0]
+	}
+	chunk := func(j int) int {
+		n := 0
+		if 2*j < len(groups) {
+			n = groups[2*j]
+		}
+		if 2*j+1 < len(groups) {
+			n += 1000 * groups[2*j+1]
+		}
+		return n
+	}
+	var parts []string
+	for j := (len(groups) - 1) / 2; j >= 1; j-- {
+		switch c := chunk(j); c {
+		case 0:
+		case 1:
+			parts = append(parts, "un "+esScales[j][0])
+		default:
+			parts = append(parts, spanishBelow1000000(c, masculine)+" "+esScales[j][1])
+		}
+	}
+	if c := chunk(0); c > 0 {
+		parts = append(parts, spanishBelow1000000(c, f))
+	}
+	return strings.Join(parts, " ")
+}
+
+func spanishBelow1000000(n int, f form) string {
+	var parts []string
+	switch t := n / 1000; {
+	case t == 1:
+		parts = append(parts, "mil")
+	case t > 1:
+		tf := f
+		if tf == standalone {
+			tf = masculine
+		}
+		parts = append(parts, spanishBelow1000(t, tf), "mil")
+	}
+	if r := n % 1000; r > 0 {
+		parts = append(parts, spanishBelow1000(r, f))
+	}
+	return strings.Join(parts, " ")
+}
+
+func spanishBelow1000(n int, f form) string {
+	h, r := n/100, n%100
+	var parts []string
+	switch {
+	case h == 1 && r == 0:
+		return "cien"
+	case h == 1:
+		parts = append(parts, esHundreds[h])
+	case h > 1 && f == feminine:
+		parts = append(parts, strings.TrimSuffix(esHundreds[h], "os")+"as")
+	case h > 1:
+		parts = append(parts, esHundreds[h])
+	}
+	if r > 0 {
+		parts = append(parts, spanishBelow100(r, f))
+	}
+	return strings.Join(parts, " ")
+}
+
+func spanishBelow100(n int, f form) string {
+	one := "uno"
+	switch f {
+	case masculine:
+		one = "un"
+	case feminine:
+		one = "una"
+	}
+	switch {
+	case n == 1:
+		return one
+	case n == 21 && f == masculine:
+		return "veintiún"
+	case n == 21:
+		return "veinti" + one
+	case n < 30:
+		return esOnes[n]
+	case n%10 == 0:
+		return esTens[n/10]
+	case n%10 == 1:
+		return esTens[n/10] + " y " + one
+	}
+	return esTens[n/10] + " y " + esOnes[n%10]
+}
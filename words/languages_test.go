@@ -0,0 +1,72 @@
+package words_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/talon-one/decimal"
+	"github.com/talon-one/decimal/words"
+)
+
+func d(s string) decimal.Decimal {
+	return decimal.MustNewFromString(s)
+}
+
+func TestLanguages(t *testing.T) {
+	testData := []struct {
+		language words.Language
+		number   string
+		expected string
+	}{
+		{language: words.English, number: "0", expected: "zero"},
+		{language: words.English, number: "15", expected: "fifteen"},
+		{language: words.English, number: "42", expected: "forty-two"},
+		{language: words.English, number: "101", expected: "one hundred one"},
+		{language: words.English, number: "1234567", expected: "one million two hundred thirty-four thousand five hundred sixty-seven"},
+		{language: words.English, number: "1000000001", expected: "one billion one"},
+		{language: words.English, number: "1E+36", expected: "one undecillion"},
+
+		{language: words.German, number: "0", expected: "null"},
+		{language: words.German, number: "1", expected: "eins"},
+		{language: words.German, number: "31", expected: "einunddreißig"},
+		{language: words.German, number: "101", expected: "einhunderteins"},
+		{language: words.German, number: "1001", expected: "eintausendeins"},
+		{language: words.German, number: "101000", expected: "einhunderteintausend"},
+		{language: words.German, number: "1234567", expected: "eine Million zweihundertvierunddreißigtausendfünfhundertsiebenundsechzig"},
+		{language: words.German, number: "21000000", expected: "einundzwanzig Millionen"},
+		{language: words.German, number: "2000000000", expected: "zwei Milliarden"},
+		{language: words.German, number: "1E+36", expected: "eine Sextillion"},
+
+		{language: words.French, number: "0", expected: "zéro"},
+		{language: words.French, number: "21", expected: "vingt et un"},
+		{language: words.French, number: "71", expected: "soixante et onze"},
+		{language: words.French, number: "77", expected: "soixante-dix-sept"},
+		{language: words.French, number: "80", expected: "quatre-vingts"},
+		{language: words.French, number: "81", expected: "quatre-vingt-un"},
+		{language: words.French, number: "91", expected: "quatre-vingt-onze"},
+		{language: words.French, number: "200", expected: "deux cents"},
+		{language: words.French, number: "201", expected: "deux cent un"},
+		{language: words.French, number: "1000", expected: "mille"},
+		{language: words.French, number: "80000", expected: "quatre-vingt mille"},
+		{language: words.French, number: "200000", expected: "deux cent mille"},
+		{language: words.French, number: "200000000", expected: "deux cents millions"},
+		{language: words.French, number: "1000000000", expected: "un milliard"},
+
+		{language: words.Spanish, number: "0", expected: "cero"},
+		{language: words.Spanish, number: "21", expected: "veintiuno"},
+		{language: words.Spanish, number: "35", expected: "treinta y cinco"},
+		{language: words.Spanish, number: "100", expected: "cien"},
+		{language: words.Spanish, number: "115", expected: "ciento quince"},
+		{language: words.Spanish, number: "500", expected: "quinientos"},
+		{language: words.Spanish, number: "21000", expected: "veintiún mil"},
+		{language: words.Spanish, number: "1000000", expected: "un millón"},
+		{language: words.Spanish, number: "1000000000", expected: "mil millones"},
+		{language: words.Spanish, number: "3000000000000", expected: "tres billones"},
+		{language: words.Spanish, number: "1E+36", expected: "un sextillón"},
+	}
+	for i, j := range testData {
+		s, err := words.Decimal(d(j.number), j.language)
+		require.NoError(t, err, "At %d", i)
+		require.Equal(t, j.expected, s, "At %d", i)
+	}
+}
@@ -0,0 +1,316 @@
+// Package words spells out decimals and amounts of money in English, German,
+// French and Spanish, as some markets require for cheques and invoice totals.
+//
+// Numbers are spelled out exactly, integer parts may have up to MaxDigits
+// digits, the largest named power is 10^36.
+package words
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/talon-one/decimal"
+)
+
+// MaxDigits is the maximum number of integer digits that can be spelled out
+const MaxDigits = 39
+
+// Language selects the language numbers are spelled out in
+type Language int
+
+const (
+	English Language = iota
+	German
+	French
+	Spanish
+)
+
+func (l Language) String() string {
+	switch l {
+	case English:
+		return "English"
+	case German:
+		return "German"
+	case French:
+		return "French"
+	case Spanish:
+		return "Spanish"
+	}
+	return fmt.Sprintf("Language(%d)", int(l))
+}
+
+// Unit is the name of a currency unit
+type Unit struct {
+	Singular string
+	Plural   string
+	// Feminine selects the feminine forms of one, such as "une" or "una"
+	Feminine bool
+}
+
+// Currency holds the unit names of a currency
+type Currency struct {
+	Major Unit
+	// Minor is the unit of the fractional part, such as cents. Without a name
+	// the fractional part is written as a ratio like 50/100.
+	Minor Unit
+}
+
+// FractionStyle determines how the fractional part of money is written
+type FractionStyle int
+
+const (
+	// FractionWords spells out the minor units, "and fifty cents"
+	FractionWords FractionStyle = iota
+	// FractionRatio writes the minor units as ratio, "and 50/100" as on cheques
+	FractionRatio
+)
+
+// Options configures Money
+type Options struct {
+	Language Language
+	Fraction FractionStyle
+	// Currency overrides the unit names of the currency of the money
+	Currency *Currency
+}
+
+type language struct {
+	spell  speller
+	minus  string
+	point  string
+	and    string
+	plural func(n *big.Int) bool
+	// of is written between round millions and the unit, "un millón de euros"
+	of         func(unit string) string
+	currencies map[string]Currency
+}
+
+func pluralUnlessOne(n *big.Int) bool {
+	return n.Cmp(big.NewInt(1)) != 0
+}
+
+var languages = map[Language]language{
+	English: {spell: english, minus: "minus", point: "point", and: "and", plural: pluralUnlessOne,
+		currencies: map[string]Currency{
+			"AUD": {Major: Unit{"dollar", "dollars", false}, Minor: Unit{"cent", "cents", false}},
+			"CAD": {Major: Unit{"dollar", "dollars", false}, Minor: Unit{"cent", "cents", false}},
+			"CHF": {Major: Unit{"franc", "francs", false}, Minor: Unit{"centime", "centimes", false}},
+			"CNY": {Major: Unit{"yuan", "yuan", false}, Minor: Unit{"fen", "fen", false}},
+			"EUR": {Major: Unit{"euro", "euros", false}, Minor: Unit{"cent", "cents", false}},
+			"GBP": {Major: Unit{"pound", "pounds", false}, Minor: Unit{"penny", "pence", false}},
+			"INR": {Major: Unit{"rupee", "rupees", false}, Minor: Unit{"paisa", "paise", false}},
+			"JPY": {Major: Unit{"yen", "yen", false}},
+			"MXN": {Major: Unit{"peso", "pesos", false}, Minor: Unit{"centavo", "centavos", false}},
+			"USD": {Major: Unit{"dollar", "dollars", false}, Minor: Unit{"cent", "cents", false}},
+		}},
+	German: {spell: german, minus: "minus", point: "Komma", and: "und", plural: pluralUnlessOne,
+		currencies: map[string]Currency{
+			"AUD": {Major: Unit{"Dollar", "Dollar", false}, Minor: Unit{"Cent", "Cent", false}},
+			"CAD": {Major: Unit{"Dollar", "Dollar", false}, Minor: Unit{"Cent", "Cent", false}},
+			"CHF": {Major: Unit{"Franken", "Franken", false}, Minor: Unit{"Rappen", "Rappen", false}},
+			"CNY": {Major: Unit{"Yuan", "Yuan", false}, Minor: Unit{"Fen", "Fen", false}},
+			"EUR": {Major: Unit{"Euro", "Euro", false}, Minor: Unit{"Cent", "Cent", false}},
+			"GBP": {Major: Unit{"Pfund", "Pfund", false}, Minor: Unit{"Penny", "Pence", false}},
+			"INR": {Major: Unit{"Rupie", "Rupien", true}, Minor: Unit{"Paisa", "Paise", false}},
+			"JPY": {Major: Unit{"Yen", "Yen", false}},
+			"MXN": {Major: Unit{"Peso", "Pesos", false}, Minor: Unit{"Centavo", "Centavos", false}},
+			"USD": {Major: Unit{"Dollar", "Dollar", false}, Minor: Unit{"Cent", "Cent", false}},
+		}},
+	French: {spell: french, minus: "moins", point: "virgule", and: "et",
+		plural: func(n *big.Int) bool { return n.Cmp(big.NewInt(2)) >= 0 },
+		of: func(unit string) string {
+			if r, _ := utf8.DecodeRuneInString(unit); strings.ContainsRune("aeiouyéèêh", r) {
+				return "d'"
+			}
+			return "de "
+		},
+		currencies: map[string]Currency{
+			"AUD": {Major: Unit{"dollar", "dollars", false}, Minor: Unit{"cent", "cents", false}},
+			"CAD": {Major: Unit{"dollar", "dollars", false}, Minor: Unit{"cent", "cents", false}},
+			"CHF": {Major: Unit{"franc", "francs", false}, Minor: Unit{"centime", "centimes", false}},
+			"CNY": {Major: Unit{"yuan", "yuans", false}, Minor: Unit{"fen", "fens", false}},
+			"EUR": {Major: Unit{"euro", "euros", false}, Minor: Unit{"centime", "centimes", false}},
+			"GBP": {Major: Unit{"livre", "livres", true}, Minor: Unit{"penny", "pence", false}},
+			"INR": {Major: Unit{"roupie", "roupies", true}, Minor: Unit{"paisa", "paisas", false}},
+			"JPY": {Major: Unit{"yen", "yens", false}},
+			"MXN": {Major: Unit{"peso", "pesos", false}, Minor: Unit{"centavo", "centavos", false}},
+			"USD": {Major: Unit{"dollar", "dollars", false}, Minor: Unit{"cent", "cents", false}},
+		}},
+	Spanish: {spell: spanish, minus: "menos", point: "coma", and: "con", plural: pluralUnlessOne,
+		of: func(string) string { return "de " },
+		currencies: map[string]Currency{
+			"AUD": {Major: Unit{"dólar", "dólares", false}, Minor: Unit{"centavo", "centavos", false}},
+			"CAD": {Major: Unit{"dólar", "dólares", false}, Minor: Unit{"centavo", "centavos", false}},
+			"CHF": {Major: Unit{"franco", "francos", false}, Minor: Unit{"céntimo", "céntimos", false}},
+			"CNY": {Major: Unit{"yuan", "yuanes", false}, Minor: Unit{"fen", "fen", false}},
+			"EUR": {Major: Unit{"euro", "euros", false}, Minor: Unit{"céntimo", "céntimos", false}},
+			"GBP": {Major: Unit{"libra", "libras", true}, Minor: Unit{"penique", "peniques", false}},
+			"INR": {Major: Unit{"rupia", "rupias", true}, Minor: Unit{"paisa", "paisas", false}},
+			"JPY": {Major: Unit{"yen", "yenes", false}},
+			"MXN": {Major: Unit{"peso", "pesos", false}, Minor: Unit{"centavo", "centavos", false}},
+			"USD": {Major: Unit{"dólar", "dólares", false}, Minor: Unit{"centavo", "centavos", false}},
+		}},
+}
+
+func lookupLanguage(l Language) (language, error) {
+	lang, ok := languages[l]
+	if !ok {
+		return language{}, fmt.Errorf("Unknown language %s", l)
+	}
+	return lang, nil
+}
+
+// LookupCurrency returns the unit names of the currency with the code in the
+// language, false if there are none
+func LookupCurrency(l Language, code string) (Currency, bool) {
+	c, ok := languages[l].currencies[strings.ToUpper(code)]
+	return c, ok
+}
+
+// Decimal spells out d in the language, the fractional digits are read one
+// by one, "twelve point zero five" for 12.05
+func Decimal(d decimal.Decimal, l Language) (string, error) {
+	lang, err := lookupLanguage(l)
+	if err != nil {
+		return "", err
+	}
+	s := d.String()
+	if d.IsNaN() || strings.Contains(s, "Inf") {
+		return "", fmt.Errorf("Cannot spell out %s", s)
+	}
+	negative := strings.HasPrefix(s, "-")
+	s = strings.TrimPrefix(s, "-")
+	integer, fraction := s, ""
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		integer, fraction = s[:i], s[i+1:]
+	}
+	n, ok := new(big.Int).SetString(integer, 10)
+	if !ok {
+		return "", fmt.Errorf("Cannot spell out %s", d)
+	}
+	groups, err := toGroups(n)
+	if err != nil {
+		return "", err
+	}
+	parts := []string{lang.spell(groups, standalone)}
+	if negative {
+		parts = append([]string{lang.minus}, parts...)
+	}
+	if fraction != "" {
+		parts = append(parts, lang.point)
+		for _, digit := range fraction {
+			parts = append(parts, lang.spell([]int{int(digit - '0')}, standalone))
+		}
+	}
+	return strings.Join(parts, " "), nil
+}
+
+// Money spells out m, such as "one thousand two hundred thirty-four euros and
+// fifty cents". The amount is rounded half to even to the minor unit of the
+// currency. Unit names come from opts.Currency or LookupCurrency, currencies
+// without names are written with their code. Amounts in currencies without
+// minor unit are spelled out like decimals.
+func Money(m decimal.Money, opts Options) (string, error) {
+	lang, err := lookupLanguage(opts.Language)
+	if err != nil {
+		return "", err
+	}
+	currency, ok := LookupCurrency(opts.Language, m.Currency())
+	if opts.Currency != nil {
+		currency = *opts.Currency
+	} else if !ok {
+		currency = Currency{Major: Unit{Singular: m.Currency(), Plural: m.Currency()}}
+	}
+
+	digits := m.MinorUnits()
+	if digits == decimal.NoMinorUnits {
+		s, err := Decimal(m.Amount(), opts.Language)
+		if err != nil {
+			return "", err
+		}
+		unit := currency.Major.Plural
+		if m.Amount().Equals(decimal.NewFromInt(1)) {
+			unit = currency.Major.Singular
+		}
+		return s + " " + unit, nil
+	}
+
+	amount := m.RoundToMinorUnits().Amount()
+	r, ok := new(big.Rat).SetString(amount.String())
+	if amount.IsNaN() || !ok {
+		return "", fmt.Errorf("Cannot spell out %s", amount)
+	}
+	negative := r.Sign() < 0
+	r.Abs(r)
+	scale := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(digits)), nil)
+	units := new(big.Int).Mul(r.Num(), scale)
+	units.Quo(units, r.Denom())
+	major, minor := new(big.Int).QuoRem(units, scale, new(big.Int))
+
+	var parts []string
+	if negative {
+		parts = append(parts, lang.minus)
+	}
+	wordsMinor := opts.Fraction == FractionWords && currency.Minor.Singular != ""
+	if major.Sign() != 0 || !wordsMinor || minor.Sign() == 0 {
+		s, err := lang.spellUnit(major, currency.Major)
+		if err != nil {
+			return "", err
+		}
+		parts = append(parts, s)
+		if digits == 0 || (wordsMinor && minor.Sign() == 0) {
+			return strings.Join(parts, " "), nil
+		}
+		parts = append(parts, lang.and)
+	}
+	if wordsMinor {
+		s, err := lang.spellUnit(minor, currency.Minor)
+		if err != nil {
+			return "", err
+		}
+		parts = append(parts, s)
+	} else {
+		parts = append(parts, fmt.Sprintf("%0*d/%d", digits, minor, scale))
+	}
+	return strings.Join(parts, " "), nil
+}
+
+// spellUnit spells out n followed by the unit in singular or plural
+func (lang language) spellUnit(n *big.Int, unit Unit) (string, error) {
+	groups, err := toGroups(n)
+	if err != nil {
+		return "", err
+	}
+	f := masculine
+	if unit.Feminine {
+		f = feminine
+	}
+	name := unit.Singular
+	if lang.plural(n) {
+		name = unit.Plural
+	}
+	s := lang.spell(groups, f) + " "
+	if million := big.NewInt(1000000); lang.of != nil && n.Cmp(million) >= 0 && new(big.Int).Rem(n, million).Sign() == 0 {
+		return s + lang.of(name) + name, nil
+	}
+	return s + name, nil
+}
+
+// toGroups splits n into groups of three digits, the least significant first
+func toGroups(n *big.Int) ([]int, error) {
+	if len(n.String()) > MaxDigits {
+		return nil, fmt.Errorf("Number %s is too large to spell out", n)
+	}
+	thousand := big.NewInt(1000)
+	n = new(big.Int).Set(n)
+	groups := []int{}
+	for {
+		g := new(big.Int)
+		n.QuoRem(n, thousand, g)
+		groups = append(groups, int(g.Int64()))
+		if n.Sign() == 0 {
+			return groups, nil
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package words_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/talon-one/decimal"
+	"github.com/talon-one/decimal/words"
+)
+
+func TestDecimal(t *testing.T) {
+	s, err := words.Decimal(d("-12.05"), words.English)
+	require.NoError(t, err)
+	require.Equal(t, "minus twelve point zero five", s)
+
+	s, err = words.Decimal(d("0.5"), words.German)
+	require.NoError(t, err)
+	require.Equal(t, "null Komma fünf", s)
+
+	s, err = words.Decimal(d("999999999999999999999999999999999999999"), words.English)
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(s, "nine hundred ninety-nine undecillion nine hundred ninety-nine decillion"))
+
+	_, err = words.Decimal(d("1E+39"), words.English)
+	require.EqualError(t, err, "Number 1000000000000000000000000000000000000000 is too large to spell out")
+
+	_, err = words.Decimal(d("Inf"), words.English)
+	require.EqualError(t, err, "Cannot spell out Infinity")
+
+	_, err = words.Decimal(d("1"), words.Language(9))
+	require.EqualError(t, err, "Unknown language Language(9)")
+}
+
+func TestMoney(t *testing.T) {
+	testData := []struct {
+		language words.Language
+		money    string
+		fraction words.FractionStyle
+		expected string
+	}{
+		{language: words.English, money: "1234.50 EUR", expected: "one thousand two hundred thirty-four euros and fifty cents"},
+		{language: words.English, money: "1234.50 EUR", fraction: words.FractionRatio, expected: "one thousand two hundred thirty-four euros and 50/100"},
+		{language: words.English, money: "1 USD", expected: "one dollar"},
+		{language: words.English, money: "1 USD", fraction: words.FractionRatio, expected: "one dollar and 00/100"},
+		{language: words.English, money: "0.01 USD", expected: "one cent"},
+		{language: words.English, money: "0 USD", expected: "zero dollars"},
+		{language: words.English, money: "-2.005 GBP", expected: "minus two pounds"},
+		{language: words.English, money: "2.015 GBP", expected: "two pounds and two pence"},
+		{language: words.English, money: "1.001 USD", expected: "one dollar"},
+		{language: words.English, money: "3 JPY", expected: "three yen"},
+		{language: words.English, money: "1.5 XAU", expected: "one point five XAU"},
+		{language: words.English, money: "12.345 KWD", expected: "twelve KWD and 345/1000"},
+
+		{language: words.German, money: "1234.50 EUR", expected: "eintausendzweihundertvierunddreißig Euro und fünfzig Cent"},
+		{language: words.German, money: "1.01 EUR", expected: "ein Euro und ein Cent"},
+		{language: words.German, money: "1 INR", expected: "eine Rupie"},
+		{language: words.German, money: "1000000 CHF", expected: "eine Million Franken"},
+
+		{language: words.French, money: "1234.50 EUR", expected: "mille deux cent trente-quatre euros et cinquante centimes"},
+		{language: words.French, money: "1.80 EUR", expected: "un euro et quatre-vingts centimes"},
+		{language: words.French, money: "0 EUR", expected: "zéro euro"},
+		{language: words.French, money: "21 GBP", expected: "vingt et une livres"},
+		{language: words.French, money: "2000000 EUR", expected: "deux millions d'euros"},
+		{language: words.French, money: "2000000 USD", expected: "deux millions de dollars"},
+		{language: words.French, money: "2000001 USD", expected: "deux millions un dollars"},
+
+		{language: words.Spanish, money: "1234.50 EUR", expected: "mil doscientos treinta y cuatro euros con cincuenta céntimos"},
+		{language: words.Spanish, money: "21 MXN", expected: "veintiún pesos"},
+		{language: words.Spanish, money: "1 USD", expected: "un dólar"},
+		{language: words.Spanish, money: "201 GBP", expected: "doscientas una libras"},
+		{language: words.Spanish, money: "1000000 EUR", expected: "un millón de euros"},
+		{language: words.Spanish, money: "0.21 EUR", fraction: words.FractionRatio, expected: "cero euros con 21/100"},
+	}
+	for i, j := range testData {
+		s, err := words.Money(decimal.MustParseMoney(j.money), words.Options{Language: j.language, Fraction: j.fraction})
+		require.NoError(t, err, "At %d", i)
+		require.Equal(t, j.expected, s, "At %d", i)
+	}
+}
+
+func TestMoneyCustomCurrency(t *testing.T) {
+	c, ok := words.LookupCurrency(words.English, "usd")
+	require.True(t, ok)
+	c.Major = words.Unit{Singular: "US dollar", Plural: "US dollars"}
+	s, err := words.Money(decimal.MustParseMoney("2.50 USD"), words.Options{Currency: &c})
+	require.NoError(t, err)
+	require.Equal(t, "two US dollars and fifty cents", s)
+
+	c = words.Currency{Major: words.Unit{Singular: "dollar", Plural: "dollars"}}
+	s, err = words.Money(decimal.MustParseMoney("2.50 USD"), words.Options{Currency: &c})
+	require.NoError(t, err)
+	require.Equal(t, "two dollars and 50/100", s)
+
+	_, ok = words.LookupCurrency(words.German, "KWD")
+	require.False(t, ok)
+}